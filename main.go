@@ -1,34 +1,367 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 
+	"github.com/imlargo/react-svelte-transpiler/pkg/migrate"
 	"github.com/imlargo/react-svelte-transpiler/pkg/transpiler"
 )
 
-// Función de ejemplo para probar el transpilador
+// Códigos de salida
+const (
+	exitOK      = 0
+	exitFailure = 1 // algún archivo no se pudo transpilar o escribir
+	exitUsage   = 2 // argumentos inválidos
+)
+
+const usage = `Uso: react-svelte-transpiler [opciones] <archivo.tsx | directorio>...
+
+Transpila componentes React (.jsx/.tsx) a componentes Svelte 5.
+
+  - Sin -o, cada archivo se escribe junto al original con extensión .svelte
+    (.ts si solo declara contextos, .svelte.ts si declara hooks use*). Los
+    demás componentes de un archivo se escriben en su propio .svelte al lado.
+  - Con un único archivo, -o indica el archivo de salida; si es un
+    directorio existente, el archivo se escribe dentro con su nombre.
+  - Con varios archivos, -o indica el directorio de salida.
+  - Un directorio se recorre de forma recursiva y los componentes se escriben
+    en un árbol equivalente dentro de -o (o junto a los originales).
+  - "-" como entrada lee de stdin y, salvo que se indique -o, escribe en stdout.
+  - "-o -" escribe en stdout.
+
+Opciones:
+`
+
+var errInvalidFlag = errors.New("opción inválida")
+
+// Opciones de la línea de comandos
+type options struct {
+	output  string
+	quiet   bool
+	verbose bool
+	todo    bool
+	onMount bool
+	jobs    int
+	include stringList
+	exclude stringList
+	inputs  []string
+}
+
+// Opción que se puede repetir
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 func main() {
-	transpiler := transpiler.NewTranspiler()
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
 
-	// Cargar input desde un archivo
-	fileContent, err := os.ReadFile("input.tsx")
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	opts, err := parseArgs(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if errors.Is(err, errInvalidFlag) {
+		return exitUsage
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error leyendo archivo: %v\n", err)
-		return
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
 	}
 
-	svelteCode, err := transpiler.TranspileComponent(string(fileContent))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		return
+	var transpilerOpts []transpiler.Option
+	if opts.todo {
+		transpilerOpts = append(transpilerOpts, transpiler.WithTodoMarkers())
+	}
+	if opts.onMount {
+		transpilerOpts = append(transpilerOpts, transpiler.WithOnMount())
+	}
+	cli := &cli{
+		opts:       opts,
+		transpiler: transpiler.NewTranspiler(transpilerOpts...),
+		stdin:      stdin,
+		stdout:     stdout,
+		stderr:     stderr,
+	}
+
+	// Los archivos consecutivos se transpilan juntos en un lote
+	failed := 0
+	var files []string
+	for _, input := range opts.inputs {
+		if isDir(input) {
+			failed += cli.convert(files)
+			files = nil
+			if !cli.migrate(input) {
+				failed++
+			}
+			continue
+		}
+		files = append(files, input)
+	}
+	failed += cli.convert(files)
+
+	if failed > 0 {
+		if len(opts.inputs) > 1 {
+			fmt.Fprintf(stderr, "%d de %d entradas no se pudieron transpilar\n", failed, len(opts.inputs))
+		}
+		return exitFailure
+	}
+	return exitOK
+}
+
+// Analizar los argumentos permitiendo mezclar opciones y archivos
+func parseArgs(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{}
+	fs := flag.NewFlagSet("react-svelte-transpiler", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.output, "o", "", "archivo o directorio de salida (\"-\" para stdout)")
+	fs.BoolVar(&opts.quiet, "quiet", false, "mostrar solo los errores")
+	fs.BoolVar(&opts.verbose, "verbose", false, "mostrar cada archivo procesado y la línea de código de cada diagnóstico")
+	fs.BoolVar(&opts.todo, "todo", false, "añadir comentarios // TODO(r2s): para lo que no se pudo convertir")
+	fs.BoolVar(&opts.onMount, "onmount", false, "convertir los useEffect con dependencias [] en onMount")
+	fs.IntVar(&opts.jobs, "j", runtime.NumCPU(), "número de archivos que se transpilan en paralelo")
+	fs.Var(&opts.include, "include", "en directorios, migrar solo los archivos que coinciden con el glob (repetible)")
+	fs.Var(&opts.exclude, "exclude", "en directorios, omitir los archivos y directorios que coinciden con el glob (repetible)")
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// El paquete flag ya mostró el error y el uso
+			return nil, errInvalidFlag
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		opts.inputs = append(opts.inputs, args[0])
+		args = args[1:]
 	}
 
-	// Save to file
-	err = os.WriteFile("output.svelte", []byte(svelteCode), 0644)
+	if opts.jobs < 1 {
+		return nil, errors.New("-j debe ser al menos 1")
+	}
+	if opts.quiet && opts.verbose {
+		return nil, errors.New("--quiet y --verbose no se pueden usar juntos")
+	}
+	if len(opts.inputs) == 0 {
+		fs.Usage()
+		return nil, errors.New("no se indicó ningún archivo de entrada")
+	}
+	stdinInputs := 0
+	for _, input := range opts.inputs {
+		if input == "-" {
+			stdinInputs++
+		}
+	}
+	if stdinInputs > 0 && len(opts.inputs) > 1 {
+		return nil, errors.New("\"-\" (stdin) solo puede usarse como única entrada")
+	}
+	return opts, nil
+}
+
+type cli struct {
+	opts       *options
+	transpiler *transpiler.Transpiler
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+}
+
+// Transpilar varios archivos en paralelo; devuelve cuántos fallaron
+func (c *cli) convert(inputs []string) int {
+	failed := 0
+	var sources []transpiler.Source
+	var loaded []string
+	for _, input := range inputs {
+		name := input
+		var content []byte
+		var err error
+		if input == "-" {
+			name = "<stdin>"
+			content, err = io.ReadAll(c.stdin)
+		} else {
+			content, err = os.ReadFile(input)
+		}
+		if err != nil {
+			fmt.Fprintf(c.stderr, "Error leyendo archivo: %v\n", err)
+			failed++
+			continue
+		}
+		sources = append(sources, transpiler.Source{Filename: name, Code: string(content)})
+		loaded = append(loaded, input)
+	}
+
+	for i, r := range c.transpiler.TranspileBatch(sources, c.opts.jobs) {
+		if !c.write(loaded[i], r) {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Mostrar los diagnósticos de un archivo y escribir el resultado; devuelve
+// false si falló
+func (c *cli) write(input string, r transpiler.BatchResult) bool {
+	if c.opts.verbose {
+		fmt.Fprintf(c.stderr, "Transpilando %s\n", r.Filename)
+	}
+	if r.Result != nil {
+		c.report(r.Result.Diagnostics)
+	}
+	if r.Err != nil {
+		if r.Result == nil {
+			fmt.Fprintf(c.stderr, "Error: %v\n", r.Err)
+		}
+		return false
+	}
+
+	output := c.outputPath(input, r.Result.Extension)
+	if err := c.writeFile(output, r.Result.Code); err != nil {
+		fmt.Fprintf(c.stderr, "Error escribiendo archivo: %v\n", err)
+		return false
+	}
+
+	// Otros componentes del mismo archivo, junto al principal
+	for _, file := range r.Result.Files {
+		path := "-"
+		if output != "-" {
+			path = filepath.Join(filepath.Dir(output), file.Name)
+		} else if _, err := fmt.Fprintf(c.stdout, "\n<!-- %s -->\n", file.Name); err != nil {
+			fmt.Fprintf(c.stderr, "Error escribiendo archivo: %v\n", err)
+			return false
+		}
+		if err := c.writeFile(path, file.Code); err != nil {
+			fmt.Fprintf(c.stderr, "Error escribiendo archivo: %v\n", err)
+			return false
+		}
+	}
+	return true
+}
+
+// Escribir el código en output, o en stdout si output es "-"
+func (c *cli) writeFile(output, code string) error {
+	if output == "-" {
+		_, err := io.WriteString(c.stdout, code)
+		return err
+	}
+	if c.opts.verbose {
+		fmt.Fprintf(c.stderr, "  -> %s\n", output)
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(output, []byte(code), 0644)
+}
+
+// Migrar un directorio completo; devuelve false si algún archivo falló
+func (c *cli) migrate(dir string) bool {
+	if c.opts.output == "-" {
+		fmt.Fprintf(c.stderr, "Error: %s es un directorio y no se puede escribir en stdout\n", dir)
+		return false
+	}
+	outDir := dir
+	if c.opts.output != "" {
+		outDir = c.opts.output
+		if len(c.opts.inputs) > 1 {
+			outDir = filepath.Join(outDir, filepath.Base(dir))
+		}
+	}
+
+	opts := migrate.Options{
+		Include: c.opts.include,
+		Exclude: append(slices.Clone(migrate.DefaultExclude), c.opts.exclude...),
+		Workers: c.opts.jobs,
+	}
+	summary, err := migrate.Migrate(c.transpiler, dir, outDir, opts)
 	if err != nil {
-		fmt.Printf("Error escribiendo archivo: %v\n", err)
-		return
+		fmt.Fprintf(c.stderr, "Error: %v\n", err)
+		return false
+	}
+
+	for _, file := range summary.Files {
+		if c.opts.verbose {
+			fmt.Fprintf(c.stderr, "Transpilando %s\n", file.Source)
+		}
+		if file.Skipped {
+			if c.opts.verbose {
+				fmt.Fprintf(c.stderr, "  omitido: no declara ningún componente\n")
+			}
+			continue
+		}
+		if file.Result != nil {
+			c.report(file.Result.Diagnostics)
+		}
+		// Los errores de sintaxis ya se mostraron como diagnóstico
+		var diag transpiler.Diagnostic
+		if file.Err != nil && !errors.As(file.Err, &diag) {
+			fmt.Fprintf(c.stderr, "Error: %s: %v\n", file.Source, file.Err)
+		}
+		if c.opts.verbose && !file.Failed() {
+			fmt.Fprintf(c.stderr, "  -> %s\n", file.Output)
+		}
+	}
+	if !c.opts.quiet {
+		fmt.Fprintf(c.stderr, "%s: %s\n", dir, summary)
+	}
+	return summary.Failed == 0
+}
+
+// Ruta de salida para una entrada según -o
+func (c *cli) outputPath(input, ext string) string {
+	switch {
+	case c.opts.output == "-":
+		return "-"
+	case input == "-" && c.opts.output == "":
+		return "-"
+	case c.opts.output != "" && len(c.opts.inputs) == 1 && !isDir(c.opts.output):
+		return c.opts.output
 	}
 
+	name := strings.TrimSuffix(input, filepath.Ext(input)) + ext
+	if c.opts.output != "" {
+		return filepath.Join(c.opts.output, filepath.Base(name))
+	}
+	return name
+}
+
+// Indica si la ruta es un directorio existente
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Mostrar los diagnósticos según el nivel de detalle
+func (c *cli) report(diags []transpiler.Diagnostic) {
+	for _, diag := range diags {
+		if c.opts.quiet && diag.Severity != transpiler.SeverityError {
+			continue
+		}
+		fmt.Fprintln(c.stderr, diag)
+		if c.opts.verbose || diag.Severity == transpiler.SeverityError {
+			if excerpt := diag.Excerpt(); excerpt != "" {
+				fmt.Fprintln(c.stderr, excerpt)
+			}
+		}
+	}
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const component = "export default function Hello({ name }: { name: string }) { return <p>Hola {name}</p>; }\n"
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		inputs  []string
+		output  string
+		wantErr string
+	}{
+		{args: []string{"a.tsx"}, inputs: []string{"a.tsx"}},
+		{args: []string{"a.tsx", "-o", "out", "b.tsx"}, inputs: []string{"a.tsx", "b.tsx"}, output: "out"},
+		{args: []string{"-quiet", "src", "-exclude", "legacy"}, inputs: []string{"src"}},
+		{args: []string{"-"}, inputs: []string{"-"}},
+		{args: nil, wantErr: "ningún archivo"},
+		{args: []string{"-j", "0", "a.tsx"}, wantErr: "-j"},
+		{args: []string{"-quiet", "-verbose", "a.tsx"}, wantErr: "juntos"},
+		{args: []string{"-", "a.tsx"}, wantErr: "única entrada"},
+		{args: []string{"-bogus", "a.tsx"}, wantErr: errInvalidFlag.Error()},
+	}
+	for _, tt := range tests {
+		opts, err := parseArgs(tt.args, io.Discard)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: error %v, se esperaba %q", tt.args, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.args, err)
+			continue
+		}
+		if !slices.Equal(opts.inputs, tt.inputs) || opts.output != tt.output {
+			t.Errorf("%q: entradas %q y salida %q, se esperaban %q y %q", tt.args, opts.inputs, opts.output, tt.inputs, tt.output)
+		}
+	}
+}
+
+// Ejecutar la CLI con la entrada estándar dada
+func runCLI(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		code  int
+	}{
+		{"ayuda", "", []string{"-h"}, exitOK},
+		{"sin entradas", "", nil, exitUsage},
+		{"opción inválida", "", []string{"-bogus", "a.tsx"}, exitUsage},
+		{"stdin", component, []string{"-"}, exitOK},
+		{"error de sintaxis", "export default function A() { return <div> }", []string{"-"}, exitFailure},
+		{"archivo inexistente", "", []string{filepath.Join(t.TempDir(), "No.tsx")}, exitFailure},
+	}
+	for _, tt := range tests {
+		if code, _, stderr := runCLI(tt.stdin, tt.args...); code != tt.code {
+			t.Errorf("%s: código %d, se esperaba %d\n%s", tt.name, code, tt.code, stderr)
+		}
+	}
+}
+
+func TestRunStdio(t *testing.T) {
+	code, stdout, stderr := runCLI(component, "-")
+	if code != exitOK || !strings.Contains(stdout, "<p>Hola {name}</p>") {
+		t.Errorf("código %d, stdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+
+	code, _, stderr = runCLI("export default function A() { return <div> }", "-")
+	if code != exitFailure || !strings.Contains(stderr, "<stdin>:1:") || !strings.Contains(stderr, "syntax-error") {
+		t.Errorf("código %d, stderr:\n%s", code, stderr)
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "Hello.tsx")
+	if err := os.WriteFile(input, []byte(component), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sin -o, junto al original
+	if code, _, stderr := runCLI("", input); code != exitOK {
+		t.Fatalf("código %d\n%s", code, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Hello.svelte")); err != nil {
+		t.Error(err)
+	}
+
+	// Un archivo con -o: el archivo de salida
+	output := filepath.Join(dir, "out", "Saludo.svelte")
+	if code, _, stderr := runCLI("", "-o", output, input); code != exitOK {
+		t.Fatalf("código %d\n%s", code, stderr)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Error(err)
+	}
+
+	// Un archivo con -o de un directorio existente: dentro, con su nombre
+	if code, _, stderr := runCLI("", "-o", filepath.Join(dir, "out"), input); code != exitOK {
+		t.Fatalf("código %d\n%s", code, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out", "Hello.svelte")); err != nil {
+		t.Error(err)
+	}
+
+	// Un archivo con -o -: stdout
+	if code, stdout, _ := runCLI("", "-o", "-", input); code != exitOK || !strings.Contains(stdout, "Hola") {
+		t.Errorf("código %d, stdout:\n%s", code, stdout)
+	}
+
+	// Un directorio: árbol equivalente en -o y resumen en stderr
+	src := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(src, "ui"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "ui", "Hello.tsx"), []byte(component), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tree := filepath.Join(dir, "tree")
+	code, _, stderr := runCLI("", "-o", tree, src)
+	if code != exitOK || !strings.Contains(stderr, "1 archivos: 1 sin avisos") {
+		t.Errorf("código %d, stderr:\n%s", code, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(tree, "ui", "Hello.svelte")); err != nil {
+		t.Error(err)
+	}
+
+	// Un directorio no se puede escribir en stdout
+	if code, _, _ := runCLI("", "-o", "-", src); code != exitFailure {
+		t.Errorf("código %d, se esperaba %d", code, exitFailure)
+	}
+}
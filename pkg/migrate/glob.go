@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Patrón glob sobre rutas relativas separadas por "/". Admite los comodines
+// de path.Match y "**" para cero o más directorios. Un patrón sin "/" se
+// compara con cualquier segmento de la ruta, como en .gitignore.
+type glob struct {
+	raw      string
+	segments []string
+}
+
+func compileGlobs(patterns []string) ([]glob, error) {
+	globs := make([]glob, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.Trim(strings.TrimPrefix(p, "./"), "/")
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			p = "**/" + p
+		}
+		segments := strings.Split(p, "/")
+		for _, s := range segments {
+			if _, err := path.Match(s, ""); err != nil {
+				return nil, fmt.Errorf("patrón inválido %q: %w", p, err)
+			}
+		}
+		globs = append(globs, glob{raw: p, segments: segments})
+	}
+	return globs, nil
+}
+
+func (g glob) match(rel string) bool {
+	return matchSegments(g.segments, strings.Split(rel, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range len(name) + 1 {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func matchAny(globs []glob, rel string) bool {
+	for _, g := range globs {
+		if g.match(rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package migrate
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		want    bool
+	}{
+		// Sin "/": cualquier segmento de la ruta
+		{"node_modules", "node_modules", true},
+		{"node_modules", "app/node_modules", true},
+		{"node_modules", "app/node_modules/react/index.js", false},
+		{"*.test.tsx", "components/Button.test.tsx", true},
+		{"*.test.tsx", "components/Button.tsx", false},
+		{".*", ".git", true},
+
+		// "**" para cero o más directorios
+		{"src/**/*.tsx", "src/App.tsx", true},
+		{"src/**/*.tsx", "src/components/forms/Input.tsx", true},
+		{"src/**/*.tsx", "lib/App.tsx", false},
+		{"src/**", "src/components/Button.tsx", true},
+		{"**/legacy/*", "app/legacy/Old.tsx", true},
+		{"**/legacy/*", "app/legacy/deep/Old.tsx", false},
+
+		// Con "/": la ruta completa, con o sin "./" inicial
+		{"components/*.tsx", "components/Button.tsx", true},
+		{"components/*.tsx", "app/components/Button.tsx", false},
+		{"./components/*.tsx", "components/Button.tsx", true},
+		{"components/", "components", true},
+	}
+	for _, tt := range tests {
+		globs, err := compileGlobs([]string{tt.pattern})
+		if err != nil {
+			t.Fatalf("%q: %v", tt.pattern, err)
+		}
+		if got := matchAny(globs, tt.rel); got != tt.want {
+			t.Errorf("%q con %q = %v, se esperaba %v", tt.pattern, tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestCompileGlobsInvalid(t *testing.T) {
+	if _, err := compileGlobs([]string{"src/[a-"}); err == nil {
+		t.Error("se esperaba un error con un patrón inválido")
+	}
+	globs, err := compileGlobs([]string{"", "/", "./"})
+	if err != nil || len(globs) != 0 {
+		t.Errorf("los patrones vacíos se ignoran: %v %v", globs, err)
+	}
+}
@@ -0,0 +1,287 @@
+// Package migrate transpila un árbol de componentes React completo y escribe
+// los componentes Svelte en un árbol de directorios equivalente.
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/imlargo/react-svelte-transpiler/pkg/transpiler"
+)
+
+// Exclusiones por defecto: dependencias, directorios ocultos y archivos que
+// no son componentes (tests e historias)
+var DefaultExclude = []string{
+	"node_modules",
+	".*",
+	"*.test.jsx", "*.test.tsx", "*.test.ts", "*.test.js",
+	"*.spec.jsx", "*.spec.tsx", "*.spec.ts", "*.spec.js",
+	"*.stories.jsx", "*.stories.tsx",
+}
+
+// Extensiones de los componentes React que se transpilan
+var componentExts = map[string]bool{".jsx": true, ".tsx": true}
+
+// Extensiones de los módulos de hooks (useAlgo.ts), que se convierten en
+// módulos .svelte.ts
+var hookExts = map[string]bool{".js": true, ".ts": true}
+
+// Indica si el archivo es un componente o un módulo de hooks
+func isSource(p string) bool {
+	ext := filepath.Ext(p)
+	if componentExts[ext] {
+		return true
+	}
+	name := strings.TrimSuffix(filepath.Base(p), ext)
+	rest, ok := strings.CutPrefix(name, "use")
+	return ok && hookExts[ext] && rest != "" && unicode.IsUpper(rune(rest[0])) && !strings.Contains(rest, ".")
+}
+
+// Opciones de la migración
+type Options struct {
+	Include []string // si no está vacío, solo se migran los archivos que coinciden
+	Exclude []string // archivos y directorios que se omiten
+	Workers int      // transpilaciones en paralelo; GOMAXPROCS si es <= 0
+}
+
+// Resultado de migrar un archivo
+type FileResult struct {
+	Source  string // ruta del componente React
+	Output  string // ruta del componente Svelte
+	Result  *transpiler.Result
+	Err     error
+	Skipped bool // no declara componentes, hooks ni contextos y no se escribe
+}
+
+// Indica si el archivo se convirtió y escribió correctamente
+func (r FileResult) Failed() bool {
+	return r.Err != nil
+}
+
+// Indica si la conversión produjo avisos
+func (r FileResult) HasWarnings() bool {
+	if r.Result == nil {
+		return false
+	}
+	for _, d := range r.Result.Diagnostics {
+		if d.Severity == transpiler.SeverityWarning {
+			return true
+		}
+	}
+	return false
+}
+
+// Resumen de una migración
+type Summary struct {
+	Files     []FileResult
+	Succeeded int // convertidos sin avisos
+	Warnings  int // convertidos con avisos
+	Failed    int
+	Skipped   int // sin nada que convertir
+}
+
+func (s *Summary) String() string {
+	return fmt.Sprintf("%d archivos: %d sin avisos, %d con avisos, %d fallidos, %d omitidos",
+		len(s.Files), s.Succeeded, s.Warnings, s.Failed, s.Skipped)
+}
+
+func (s *Summary) add(r FileResult) {
+	s.Files = append(s.Files, r)
+	switch {
+	case r.Failed():
+		s.Failed++
+	case r.Skipped:
+		s.Skipped++
+	case r.HasWarnings():
+		s.Warnings++
+	default:
+		s.Succeeded++
+	}
+}
+
+// Recorrer srcDir, transpilar cada componente .jsx/.tsx y cada módulo de
+// hooks y escribir el resultado en la ruta equivalente dentro de outDir
+// (.svelte, .ts o .svelte.ts según el caso). Los fallos de un
+// archivo se registran en el resumen; solo se devuelve error si no se puede
+// recorrer el árbol o las opciones no son válidas.
+func Migrate(t *transpiler.Transpiler, srcDir, outDir string, opts Options) (*Summary, error) {
+	sources, err := Find(srcDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leer todos los componentes y transpilarlos en paralelo
+	files := make([]FileResult, len(sources))
+	var batch []transpiler.Source
+	var pending []int
+	for i, src := range sources {
+		files[i] = FileResult{Source: src}
+		files[i].Output, files[i].Err = OutputPath(srcDir, outDir, src, ".svelte")
+		if files[i].Err != nil {
+			continue
+		}
+		content, err := os.ReadFile(src)
+		if err != nil {
+			files[i].Err = fmt.Errorf("leyendo archivo: %w", err)
+			continue
+		}
+		batch = append(batch, transpiler.Source{Filename: src, Code: string(content)})
+		pending = append(pending, i)
+	}
+	for j, r := range t.TranspileBatch(batch, opts.Workers) {
+		file := &files[pending[j]]
+		file.Result, file.Err = r.Result, r.Err
+		if r.Result == nil {
+			continue
+		}
+		// Un .tsx con solo tipos o utilidades no es un componente; los
+		// módulos de contextos y de hooks se escriben como .ts y .svelte.ts
+		switch {
+		case r.Err == nil && r.Result.Extension == ".svelte" && r.Result.Component == "":
+			file.Skipped = true
+		case r.Result.Extension != ".svelte":
+			file.Output, _ = OutputPath(srcDir, outDir, file.Source, r.Result.Extension)
+		}
+	}
+
+	// Los imports sin extensión de los archivos convertidos apuntan a la
+	// nueva extensión
+	imports := make(map[string]string)
+	for _, file := range files {
+		if file.Err == nil && !file.Skipped {
+			imports[strings.TrimSuffix(file.Source, filepath.Ext(file.Source))] = importExtension(file.Result.Extension)
+		}
+	}
+
+	summary := &Summary{}
+	for _, file := range files {
+		if file.Err == nil && !file.Skipped {
+			rewriteImports(file.Source, file.Result, imports)
+			file.Err = writeOutputs(file.Output, file.Result)
+		}
+		summary.add(file)
+	}
+	return summary, nil
+}
+
+// Imports relativos: import X from './X' o '../hooks/useX'
+var relativeImport = regexp.MustCompile(`(\bfrom\s*|\bimport\s*)(['"])(\.\.?/[^'"\n]*)['"]`)
+
+// Extensión con la que se importa un archivo convertido: los componentes con
+// .svelte y los módulos de hooks con .svelte.js, que TypeScript resuelve al
+// .svelte.ts; los módulos .ts se importan igual que antes
+func importExtension(ext string) string {
+	switch ext {
+	case ".svelte":
+		return ".svelte"
+	case ".svelte.ts":
+		return ".svelte.js"
+	}
+	return ""
+}
+
+// Añadir a los imports relativos sin extensión la extensión del archivo
+// convertido al que apuntan; imports asocia cada fuente sin extensión con
+// la extensión de su import
+func rewriteImports(src string, result *transpiler.Result, imports map[string]string) {
+	rewrite := func(code string) string {
+		return relativeImport.ReplaceAllStringFunc(code, func(m string) string {
+			parts := relativeImport.FindStringSubmatch(m)
+			spec := parts[3]
+			ext := imports[filepath.Join(filepath.Dir(src), filepath.FromSlash(spec))]
+			if ext == "" {
+				return m
+			}
+			return parts[1] + parts[2] + spec + ext + parts[2]
+		})
+	}
+	result.Code = rewrite(result.Code)
+	for i := range result.Files {
+		result.Files[i].Code = rewrite(result.Files[i].Code)
+	}
+}
+
+// Componentes de srcDir que coinciden con las opciones, en orden léxico
+func Find(srcDir string, opts Options) ([]string, error) {
+	include, err := compileGlobs(opts.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compileGlobs(opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	err = filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matchAny(exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !isSource(p) {
+			return nil
+		}
+		if len(include) > 0 && !matchAny(include, rel) {
+			return nil
+		}
+		sources = append(sources, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recorriendo %s: %w", srcDir, err)
+	}
+	return sources, nil
+}
+
+// Ruta equivalente a src dentro de outDir con la extensión ext
+func OutputPath(srcDir, outDir, src, ext string) (string, error) {
+	rel, err := filepath.Rel(srcDir, src)
+	if err != nil {
+		return "", err
+	}
+	rel = strings.TrimSuffix(rel, filepath.Ext(rel)) + ext
+	return filepath.Join(outDir, rel), nil
+}
+
+// Escribir el resultado y los demás componentes del mismo archivo, junto al
+// principal
+func writeOutputs(output string, result *transpiler.Result) error {
+	if err := writeFile(output, result.Code); err != nil {
+		return err
+	}
+	for _, extra := range result.Files {
+		if err := writeFile(filepath.Join(filepath.Dir(output), extra.Name), extra.Code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(output, code string) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("creando directorio: %w", err)
+	}
+	if err := os.WriteFile(output, []byte(code), 0644); err != nil {
+		return fmt.Errorf("escribiendo archivo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/imlargo/react-svelte-transpiler/pkg/transpiler"
+)
+
+// Crear los archivos en dir; las rutas usan "/"
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const button = "export default function Button() { return <button>Ok</button>; }\n"
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"src/App.tsx":                    button,
+		"src/App.stories.tsx":            button,
+		"src/components/Button.tsx":      button,
+		"src/components/Button.test.tsx": button,
+		"src/components/legacy/Old.jsx":  button,
+		"src/hooks/useCart.ts":           "",
+		"src/hooks/useragent.ts":         "",
+		"src/hooks/use.ts":               "",
+		"src/utils/format.ts":            "",
+		"src/styles.css":                 "",
+		"node_modules/lib/Index.tsx":     button,
+		".storybook/Preview.tsx":         button,
+	})
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{"por defecto", Options{Exclude: DefaultExclude}, []string{
+			"src/App.tsx", "src/components/Button.tsx", "src/components/legacy/Old.jsx", "src/hooks/useCart.ts",
+		}},
+		{"include", Options{Include: []string{"src/components/**"}, Exclude: DefaultExclude}, []string{
+			"src/components/Button.tsx", "src/components/legacy/Old.jsx",
+		}},
+		{"exclude de un directorio", Options{Exclude: append(slices.Clone(DefaultExclude), "legacy")}, []string{
+			"src/App.tsx", "src/components/Button.tsx", "src/hooks/useCart.ts",
+		}},
+		{"sin exclusiones", Options{Include: []string{"*.tsx"}}, []string{
+			".storybook/Preview.tsx", "node_modules/lib/Index.tsx", "src/App.stories.tsx", "src/App.tsx",
+			"src/components/Button.test.tsx", "src/components/Button.tsx",
+		}},
+	}
+	for _, tt := range tests {
+		found, err := Find(dir, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var got []string
+		for _, p := range found {
+			rel, _ := filepath.Rel(dir, p)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %q, se esperaba %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := Find(dir, Options{Include: []string{"[a-"}}); err == nil {
+		t.Error("se esperaba un error con un patrón inválido")
+	}
+	if _, err := Find(filepath.Join(dir, "no-existe"), Options{}); err == nil {
+		t.Error("se esperaba un error con un directorio que no existe")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	src, out := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{
+		"Button.tsx":       button,
+		"forms/Input.tsx":  "export default function Input({ value }: { value: string }) { return <input value={value} />; }\n",
+		"forms/Broken.tsx": "export default function Broken() { return <div>; }\n",
+		"hooks/useFlag.ts": "import { useState } from 'react';\nexport function useFlag() { const [on, setOn] = useState(false); return { on, toggle: () => setOn(!on) }; }\n",
+		"Dropped.tsx":      "export default function Dropped() { useFoo(); return <p />; }\n",
+		"format.tsx":       "export const money = (n: number) => n.toFixed(2);\n",
+		"forms/Form.tsx": "import Input from './Input';\nimport Button from '../Button';\nimport { useFlag } from '../hooks/useFlag';\nimport { money } from '../format';\n" +
+			"export default function Form() { const { on } = useFlag(); return <form><Input value={money(1)} />{on && <Button />}</form>; }\n",
+		"node_modules/X.tsx": button,
+	})
+
+	summary, err := Migrate(transpiler.NewTranspiler(), src, out, Options{Exclude: DefaultExclude, Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Files) != 7 || summary.Succeeded != 4 || summary.Warnings != 1 || summary.Failed != 1 || summary.Skipped != 1 {
+		t.Errorf("resumen inesperado: %s", summary)
+	}
+
+	for _, name := range []string{"Button.svelte", "Dropped.svelte", "forms/Form.svelte", "forms/Input.svelte", "hooks/useFlag.svelte.ts"} {
+		if _, err := os.Stat(filepath.Join(out, filepath.FromSlash(name))); err != nil {
+			t.Errorf("no se escribió %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"forms/Broken.svelte", "format.svelte", "node_modules"} {
+		if _, err := os.Stat(filepath.Join(out, filepath.FromSlash(name))); err == nil {
+			t.Errorf("no se esperaba %s", name)
+		}
+	}
+	for _, file := range summary.Files {
+		if strings.HasSuffix(file.Source, "Broken.tsx") && !file.Failed() {
+			t.Errorf("%s debería fallar", file.Source)
+		}
+	}
+
+	// Los imports sin extensión apuntan a los archivos convertidos
+	form, err := os.ReadFile(filepath.Join(out, "forms", "Form.svelte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, imp := range []string{"from './Input.svelte'", "from '../Button.svelte'", "from '../hooks/useFlag.svelte.js'", "from '../format'"} {
+		if !strings.Contains(string(form), imp) {
+			t.Errorf("Form.svelte no contiene %s:\n%s", imp, form)
+		}
+	}
+}
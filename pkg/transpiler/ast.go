@@ -0,0 +1,613 @@
+package transpiler
+
+// Rango [start, end) dentro del código fuente
+type span struct {
+	start int
+	end   int
+}
+
+func (s span) bounds() (int, int) {
+	return s.start, s.end
+}
+
+// Nodo del árbol sintáctico de TypeScript + JSX
+type node interface {
+	bounds() (int, int)
+}
+
+// ---- Declaraciones y sentencias ----
+
+type program struct {
+	span
+	body []node
+}
+
+type importSpec struct {
+	imported string
+	local    string
+	typeOnly bool
+}
+
+type importDecl struct {
+	span
+	source      string
+	defaultName string
+	namespace   string
+	named       []importSpec
+	typeOnly    bool
+}
+
+type exportDecl struct {
+	span
+	isDefault bool
+	decl      node // declaración exportada (función, variable, tipo...)
+	expr      node // export default <expresión>
+	names     []string
+	source    string
+}
+
+type varDecl struct {
+	span
+	kind  string // const, let, var, using o await using
+	decls []*declarator
+}
+
+type declarator struct {
+	span
+	target node // identificador o patrón de desestructuración
+	typ    string
+	init   node
+}
+
+type funcDecl struct {
+	span
+	name string
+	fn   *function
+}
+
+type classDecl struct {
+	span
+	name string
+}
+
+type interfaceDecl struct {
+	span
+	name       string
+	typeParams string
+	extends    string
+	members    []*typeMember
+}
+
+type typeAlias struct {
+	span
+	name       string
+	typeParams string
+	typ        string
+	members    []*typeMember // solo si el tipo es un objeto literal
+}
+
+type typeMember struct {
+	span
+	name     string
+	optional bool
+	typ      string
+}
+
+type enumDecl struct {
+	span
+	name string
+}
+
+// namespace, module o declare global; no se convierte
+type namespaceDecl struct {
+	span
+	name string
+}
+
+type block struct {
+	span
+	body []node
+}
+
+type exprStmt struct {
+	span
+	expr node
+}
+
+type returnStmt struct {
+	span
+	arg node
+}
+
+type ifStmt struct {
+	span
+	test node
+	cons node
+	alt  node
+}
+
+type forStmt struct {
+	span
+	init   node
+	test   node
+	update node
+	body   node
+}
+
+type forInStmt struct {
+	span
+	left  node
+	right node
+	body  node
+	of    bool
+}
+
+type whileStmt struct {
+	span
+	test node
+	body node
+}
+
+type doWhileStmt struct {
+	span
+	body node
+	test node
+}
+
+type switchStmt struct {
+	span
+	disc  node
+	cases []*switchCase
+}
+
+type switchCase struct {
+	span
+	test node // nil para default
+	body []node
+}
+
+type tryStmt struct {
+	span
+	block     *block
+	param     node
+	handler   *block
+	finalizer *block
+}
+
+type throwStmt struct {
+	span
+	arg node
+}
+
+type jumpStmt struct {
+	span
+	keyword string // break o continue
+	label   string
+}
+
+type labeledStmt struct {
+	span
+	label string
+	body  node
+}
+
+type emptyStmt struct {
+	span
+}
+
+// ---- Expresiones ----
+
+type ident struct {
+	span
+	name string
+}
+
+type literalKind int
+
+const (
+	litNumber literalKind = iota
+	litString
+	litRegex
+	litBool
+	litNull
+)
+
+type literal struct {
+	span
+	kind literalKind
+	raw  string
+}
+
+type templateLit struct {
+	span
+	exprs []node
+}
+
+type taggedTemplate struct {
+	span
+	tag   node
+	quasi *templateLit
+}
+
+type arrayLit struct {
+	span
+	elems []node // nil para huecos
+}
+
+type objectLit struct {
+	span
+	props []*property
+}
+
+type property struct {
+	span
+	key       node
+	computed  bool
+	value     node
+	shorthand bool
+	spread    bool
+	def       node // valor por defecto en un shorthand usado como patrón
+}
+
+type spreadElem struct {
+	span
+	arg node
+}
+
+type function struct {
+	span
+	name       string
+	async      bool
+	generator  bool
+	arrow      bool
+	typeParams string
+	params     []*param
+	returnType string
+	body       *block // nil en arrow functions con cuerpo de expresión
+	expr       node
+}
+
+type param struct {
+	span
+	target   node
+	typ      string
+	def      node
+	rest     bool
+	optional bool
+}
+
+type classExpr struct {
+	span
+	name string
+}
+
+type callExpr struct {
+	span
+	callee   node
+	typeArgs string
+	args     []node
+	optional bool
+}
+
+// Llamada a un hook de React (useState, useEffect, React.useMemo...)
+type hookCall struct {
+	callExpr
+	hook string
+}
+
+type newExpr struct {
+	span
+	callee node
+	args   []node
+}
+
+type memberExpr struct {
+	span
+	object   node
+	property string
+	optional bool
+}
+
+type indexExpr struct {
+	span
+	object   node
+	index    node
+	optional bool
+}
+
+type unaryExpr struct {
+	span
+	op  string
+	arg node
+}
+
+type updateExpr struct {
+	span
+	op     string
+	prefix bool
+	arg    node
+}
+
+type binaryExpr struct {
+	span
+	op    string
+	left  node
+	right node
+}
+
+type conditionalExpr struct {
+	span
+	test node
+	cons node
+	alt  node
+}
+
+type assignExpr struct {
+	span
+	op     string
+	target node
+	value  node
+}
+
+type sequenceExpr struct {
+	span
+	exprs []node
+}
+
+type parenExpr struct {
+	span
+	expr node
+}
+
+// Aserciones de TypeScript: x as T, x satisfies T
+type asExpr struct {
+	span
+	expr node
+	op   string
+	typ  string
+}
+
+type nonNullExpr struct {
+	span
+	expr node
+}
+
+// ---- Patrones de desestructuración ----
+
+type objectPattern struct {
+	span
+	props []*bindingProp
+}
+
+type bindingProp struct {
+	span
+	key       node
+	computed  bool
+	value     node
+	def       node
+	shorthand bool
+	rest      bool
+}
+
+type arrayPattern struct {
+	span
+	elems []*bindingElem // nil para huecos
+}
+
+type bindingElem struct {
+	span
+	target node
+	def    node
+	rest   bool
+}
+
+// ---- JSX ----
+
+type jsxElement struct {
+	span
+	name        string
+	attrs       []node // *jsxAttr o *jsxSpreadAttr
+	children    []node
+	selfClosing bool
+	openEnd     int // fin de la etiqueta de apertura
+}
+
+type jsxFragment struct {
+	span
+	children []node
+}
+
+type jsxAttr struct {
+	span
+	name  string
+	value node // nil, *literal, *jsxExprContainer o *jsxElement
+}
+
+type jsxSpreadAttr struct {
+	span
+	arg node
+}
+
+type jsxText struct {
+	span
+	raw string
+}
+
+type jsxExprContainer struct {
+	span
+	expr node // nil si el contenedor está vacío o solo tiene comentarios
+}
+
+// Hijos directos de un nodo en orden de aparición en el código fuente
+func children(n node) []node {
+	var out []node
+	add := func(nodes ...node) {
+		for _, c := range nodes {
+			if c != nil {
+				out = append(out, c)
+			}
+		}
+	}
+
+	switch n := n.(type) {
+	case *program:
+		add(n.body...)
+	case *exportDecl:
+		add(n.decl, n.expr)
+	case *varDecl:
+		for _, d := range n.decls {
+			add(d)
+		}
+	case *declarator:
+		add(n.target, n.init)
+	case *funcDecl:
+		add(n.fn)
+	case *block:
+		add(n.body...)
+	case *exprStmt:
+		add(n.expr)
+	case *returnStmt:
+		add(n.arg)
+	case *ifStmt:
+		add(n.test, n.cons, n.alt)
+	case *forStmt:
+		add(n.init, n.test, n.update, n.body)
+	case *forInStmt:
+		add(n.left, n.right, n.body)
+	case *whileStmt:
+		add(n.test, n.body)
+	case *doWhileStmt:
+		add(n.body, n.test)
+	case *switchStmt:
+		add(n.disc)
+		for _, c := range n.cases {
+			add(c)
+		}
+	case *switchCase:
+		add(n.test)
+		add(n.body...)
+	case *tryStmt:
+		add(n.block)
+		add(n.param)
+		if n.handler != nil {
+			add(n.handler)
+		}
+		if n.finalizer != nil {
+			add(n.finalizer)
+		}
+	case *throwStmt:
+		add(n.arg)
+	case *labeledStmt:
+		add(n.body)
+	case *templateLit:
+		add(n.exprs...)
+	case *taggedTemplate:
+		add(n.tag, n.quasi)
+	case *arrayLit:
+		add(n.elems...)
+	case *objectLit:
+		for _, p := range n.props {
+			add(p)
+		}
+	case *property:
+		if n.shorthand {
+			add(n.value, n.def)
+		} else {
+			add(n.key, n.value)
+		}
+	case *spreadElem:
+		add(n.arg)
+	case *function:
+		for _, p := range n.params {
+			add(p)
+		}
+		if n.body != nil {
+			add(n.body)
+		}
+		add(n.expr)
+	case *param:
+		add(n.target, n.def)
+	case *callExpr:
+		add(n.callee)
+		add(n.args...)
+	case *hookCall:
+		add(n.callee)
+		add(n.args...)
+	case *newExpr:
+		add(n.callee)
+		add(n.args...)
+	case *memberExpr:
+		add(n.object)
+	case *indexExpr:
+		add(n.object, n.index)
+	case *unaryExpr:
+		add(n.arg)
+	case *updateExpr:
+		add(n.arg)
+	case *binaryExpr:
+		add(n.left, n.right)
+	case *conditionalExpr:
+		add(n.test, n.cons, n.alt)
+	case *assignExpr:
+		add(n.target, n.value)
+	case *sequenceExpr:
+		add(n.exprs...)
+	case *parenExpr:
+		add(n.expr)
+	case *asExpr:
+		add(n.expr)
+	case *nonNullExpr:
+		add(n.expr)
+	case *objectPattern:
+		for _, p := range n.props {
+			add(p)
+		}
+	case *bindingProp:
+		if n.shorthand {
+			add(n.value, n.def)
+		} else {
+			add(n.key, n.value, n.def)
+		}
+	case *arrayPattern:
+		for _, e := range n.elems {
+			if e != nil {
+				add(e)
+			}
+		}
+	case *bindingElem:
+		add(n.target, n.def)
+	case *jsxElement:
+		add(n.attrs...)
+		add(n.children...)
+	case *jsxFragment:
+		add(n.children...)
+	case *jsxAttr:
+		add(n.value)
+	case *jsxSpreadAttr:
+		add(n.arg)
+	case *jsxExprContainer:
+		add(n.expr)
+	}
+	return out
+}
+
+// Recorrer el árbol en profundidad; si fn devuelve false no se visitan los hijos
+func walk(n node, fn func(node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	for _, c := range children(n) {
+		walk(c, fn)
+	}
+}
+
+// Quitar paréntesis redundantes alrededor de una expresión
+func unparen(n node) node {
+	for {
+		p, ok := n.(*parenExpr)
+		if !ok {
+			return n
+		}
+		n = p.expr
+	}
+}
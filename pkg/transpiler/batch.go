@@ -0,0 +1,65 @@
+package transpiler
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Componente de entrada para la transpilación por lotes
+type Source struct {
+	Filename string
+	Code     string
+}
+
+// Resultado de transpilar un Source; Result y Err son los de TranspileComponent
+type BatchResult struct {
+	Filename string
+	Result   *Result
+	Err      error
+}
+
+// Transpilar varios componentes en paralelo con como máximo workers
+// goroutines (GOMAXPROCS si workers <= 0). Los resultados se devuelven en el
+// mismo orden que sources. Es seguro porque cada llamada a
+// TranspileComponent trabaja sobre su propio estado y el Transpiler solo se
+// lee. Un panic al convertir un archivo se devuelve como su Err, sin
+// detener el resto del lote.
+func (t *Transpiler) TranspileBatch(sources []Source, workers int) []BatchResult {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(sources))
+
+	results := make([]BatchResult, len(sources))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = t.transpileSource(sources[i])
+			}
+		}()
+	}
+
+	for i := range sources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// Transpilar un Source recuperando los panics del conversor como error
+func (t *Transpiler) transpileSource(src Source) (r BatchResult) {
+	r.Filename = src.Filename
+	defer func() {
+		if p := recover(); p != nil {
+			r.Result, r.Err = nil, fmt.Errorf("error interno al transpilar %s: %v", src.Filename, p)
+		}
+	}()
+	r.Result, r.Err = t.TranspileComponent(src.Filename, src.Code)
+	return r
+}
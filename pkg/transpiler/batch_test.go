@@ -0,0 +1,63 @@
+package transpiler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// El lote devuelve los mismos resultados que las llamadas secuenciales, en el
+// orden de entrada, con cualquier número de workers
+func TestTranspileBatchOrder(t *testing.T) {
+	tr := NewTranspiler()
+	var sources []Source
+	for range 4 {
+		sources = append(sources, loadCorpus(t)...)
+	}
+	sources = append(sources, Source{Filename: "Broken.tsx", Code: "export default function Broken() { return <div> }"})
+
+	want := make([]BatchResult, len(sources))
+	for i, src := range sources {
+		result, err := tr.TranspileComponent(src.Filename, src.Code)
+		want[i] = BatchResult{Filename: src.Filename, Result: result, Err: err}
+	}
+
+	for _, workers := range []int{0, 1, 3, 8, len(sources) + 5} {
+		got := tr.TranspileBatch(sources, workers)
+		if len(got) != len(want) {
+			t.Fatalf("workers=%d: %d resultados, se esperaban %d", workers, len(got), len(want))
+		}
+		for i := range want {
+			if !reflect.DeepEqual(got[i], want[i]) {
+				t.Errorf("workers=%d: el resultado %d (%s) no coincide con la llamada secuencial", workers, i, sources[i].Filename)
+			}
+		}
+	}
+}
+
+func TestTranspileBatchEmpty(t *testing.T) {
+	if got := NewTranspiler().TranspileBatch(nil, 4); len(got) != 0 {
+		t.Errorf("se esperaba un lote vacío, se obtuvieron %d resultados", len(got))
+	}
+}
+
+// Un panic al convertir un archivo se devuelve como su error
+func TestTranspileBatchRecover(t *testing.T) {
+	// Un Transpiler nil falla al leer sus opciones
+	var tr *Transpiler
+	sources := []Source{
+		{Filename: "A.tsx", Code: "export default function A() { return <p /> }"},
+		{Filename: "B.tsx", Code: "export default function B() { return <p /> }"},
+	}
+	for i, r := range tr.TranspileBatch(sources, 2) {
+		if r.Filename != sources[i].Filename {
+			t.Errorf("resultado %d: Filename = %q, se esperaba %q", i, r.Filename, sources[i].Filename)
+		}
+		if r.Err == nil || !strings.Contains(r.Err.Error(), "error interno") {
+			t.Errorf("%s: se esperaba el panic como error, se obtuvo %v", r.Filename, r.Err)
+		}
+		if r.Result != nil {
+			t.Errorf("%s: no se esperaba un Result", r.Filename)
+		}
+	}
+}
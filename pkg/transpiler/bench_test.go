@@ -0,0 +1,97 @@
+package transpiler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Componentes de testdata/, usados como corpus realista
+func loadCorpus(tb testing.TB) []Source {
+	tb.Helper()
+	var sources []Source
+	for _, pattern := range []string{"testdata/*.tsx", "testdata/*.jsx", "testdata/use*.ts"} {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		for _, path := range paths {
+			// Salida esperada de un hook, no una entrada
+			if strings.HasSuffix(path, ".svelte.ts") {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				tb.Fatal(err)
+			}
+			sources = append(sources, Source{Filename: filepath.Base(path), Code: string(content)})
+		}
+	}
+	if len(sources) == 0 {
+		tb.Fatal("testdata/ no contiene componentes")
+	}
+	return sources
+}
+
+func BenchmarkTranspileComponent(b *testing.B) {
+	t := NewTranspiler()
+	for _, src := range loadCorpus(b) {
+		b.Run(src.Filename, func(b *testing.B) {
+			b.SetBytes(int64(len(src.Code)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := t.TranspileComponent(src.Filename, src.Code); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// Referencia: la extracción por expresiones regulares del commit inicial
+// (4f7740e) tardaba unos 15 ms/op con 7,9 MB y 64 500 asignaciones por
+// operación sobre los mismos 42 archivos; el parser, unos 6,2 ms/op con 1,4 MB
+// y 33 800 asignaciones (go test -bench TranspileCorpus -count 6, misma
+// máquina)
+func BenchmarkTranspileCorpus(b *testing.B) {
+	t := NewTranspiler()
+	corpus := loadCorpus(b)
+	var size int64
+	for _, src := range corpus {
+		size += int64(len(src.Code))
+	}
+	b.SetBytes(size)
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, src := range corpus {
+			if _, err := t.TranspileComponent(src.Filename, src.Code); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTranspileBatch(b *testing.B) {
+	t := NewTranspiler()
+	corpus := loadCorpus(b)
+
+	// Simular un proyecto con cientos de componentes
+	var project []Source
+	for range 50 {
+		project = append(project, corpus...)
+	}
+	var size int64
+	for _, src := range project {
+		size += int64(len(src.Code))
+	}
+	b.SetBytes(size)
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, r := range t.TranspileBatch(project, 0) {
+			if r.Err != nil {
+				b.Fatal(r.Err)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package transpiler
+
+// Los inputs controlados de React (value={name} con un onChange que solo
+// guarda e.target.value en el estado) se convierten en bind:value={name} y
+// bind:checked={flag}, sin el manejador.
+
+// Elementos de formulario que admiten bind:value
+var formElements = map[string]bool{"input": true, "select": true, "textarea": true}
+
+// Detectar los atributos value/checked controlados de un elemento: se
+// sustituyen por bind: y se omite su onChange
+func (c *converter) bindControlled(el *jsxElement) {
+	if !formElements[el.name] {
+		return
+	}
+	inputType := ""
+	attrs := make(map[string]*jsxAttr)
+	for _, a := range el.attrs {
+		if attr, ok := a.(*jsxAttr); ok {
+			attrs[attr.name] = attr
+		}
+	}
+	if attr := attrs["type"]; attr != nil {
+		if lit, ok := attr.value.(*literal); ok && lit.kind == litString {
+			inputType = lit.raw[1 : len(lit.raw)-1]
+		}
+	}
+
+	property := "value"
+	switch inputType {
+	case "checkbox":
+		property = "checked"
+	case "radio", "file":
+		return
+	}
+	valueAttr, handler := attrs[property], attrs["onChange"]
+	if handler == nil {
+		handler = attrs["onInput"]
+	}
+	if valueAttr == nil || handler == nil {
+		return
+	}
+	target := c.controlledTarget(valueAttr)
+	if target == nil || !c.writesTarget(handler, target, property, inputType == "number" || inputType == "range") {
+		return
+	}
+	c.skip[handler] = true
+	c.bindings[valueAttr] = "bind:" + property + "={" + c.print(target) + "}"
+}
+
+// Estado que muestra el atributo: name o form.name
+func (c *converter) controlledTarget(attr *jsxAttr) node {
+	container, ok := attr.value.(*jsxExprContainer)
+	if !ok || container.expr == nil {
+		return nil
+	}
+	switch e := unparen(container.expr).(type) {
+	case *ident:
+		if _, isState := c.stateTypes[e.name]; isState {
+			return e
+		}
+	case *memberExpr:
+		if obj, ok := e.object.(*ident); ok && !e.optional {
+			if _, isState := c.stateTypes[obj.name]; isState {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// Indica si el manejador solo guarda e.target.value (o checked) en el estado:
+// setName(e.target.value) o setForm({ ...form, name: e.target.value })
+func (c *converter) writesTarget(handler *jsxAttr, target node, property string, numeric bool) bool {
+	container, ok := handler.value.(*jsxExprContainer)
+	if !ok || container.expr == nil {
+		return false
+	}
+	fn, ok := unparen(container.expr).(*function)
+	if !ok || len(fn.params) != 1 {
+		return false
+	}
+	event, ok := fn.params[0].target.(*ident)
+	if !ok {
+		return false
+	}
+	expr := fn.expr
+	if fn.body != nil {
+		if len(fn.body.body) != 1 {
+			return false
+		}
+		stmt, ok := fn.body.body[0].(*exprStmt)
+		if !ok {
+			return false
+		}
+		expr = stmt.expr
+	}
+	call, ok := unparen(expr).(*callExpr)
+	if !ok || len(call.args) != 1 {
+		return false
+	}
+	setter, ok := call.callee.(*ident)
+	if !ok {
+		return false
+	}
+	state := c.setters[setter.name]
+
+	switch t := target.(type) {
+	case *ident:
+		return state == t.name && isEventValue(call.args[0], event.name, property, numeric)
+	case *memberExpr:
+		// setForm({ ...form, name: e.target.value })
+		obj := t.object.(*ident)
+		update, ok := unparen(call.args[0]).(*objectLit)
+		if state != obj.name || !ok || len(update.props) != 2 || !update.props[0].spread {
+			return false
+		}
+		spread, ok := unparen(update.props[0].value).(*ident)
+		key, isIdent := update.props[1].key.(*ident)
+		return ok && spread.name == obj.name && isIdent && key.name == t.property &&
+			!update.props[1].computed && isEventValue(update.props[1].value, event.name, property, numeric)
+	}
+	return false
+}
+
+// e.target.value o e.currentTarget.value; en los inputs numéricos también
+// Number(e.target.value), +e.target.value y e.target.valueAsNumber. parseInt
+// solo en base 10: con otra base el valor no es el que enlaza bind:value
+func isEventValue(n node, event, property string, numeric bool) bool {
+	n = unparen(n)
+	if numeric {
+		switch v := n.(type) {
+		case *callExpr:
+			fn, ok := v.callee.(*ident)
+			if !ok {
+				break
+			}
+			switch {
+			case (fn.name == "Number" || fn.name == "parseFloat") && len(v.args) == 1,
+				fn.name == "parseInt" && (len(v.args) == 1 || len(v.args) == 2 && isDecimalRadix(v.args[1])):
+				return isEventValue(v.args[0], event, property, false)
+			}
+		case *unaryExpr:
+			if v.op == "+" {
+				return isEventValue(v.arg, event, property, false)
+			}
+		case *memberExpr:
+			if v.property == "valueAsNumber" {
+				return isEventTarget(v.object, event)
+			}
+		}
+	}
+	member, ok := n.(*memberExpr)
+	return ok && member.property == property && isEventTarget(member.object, event)
+}
+
+// Literal 10 pasado como base de parseInt
+func isDecimalRadix(n node) bool {
+	lit, ok := unparen(n).(*literal)
+	return ok && lit.kind == litNumber && lit.raw == "10"
+}
+
+// e.target o e.currentTarget
+func isEventTarget(n node, event string) bool {
+	member, ok := unparen(n).(*memberExpr)
+	if !ok || (member.property != "target" && member.property != "currentTarget") {
+		return false
+	}
+	id, ok := member.object.(*ident)
+	return ok && id.name == event
+}
@@ -0,0 +1,485 @@
+package transpiler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Un archivo puede declarar varios componentes. El principal (el exportado
+// por defecto o el primero que devuelve JSX) se convierte en el resultado y
+// el resto en archivos .svelte propios, salvo los pequeños que solo usa el
+// principal, que pasan a ser {#snippet} dentro de su markup.
+
+// Líneas máximas de un componente que se convierte en snippet
+const snippetMaxLines = 15
+
+// Funciones que envuelven un componente: memo, forwardRef y sus variantes
+// React.memo y React.forwardRef
+type componentWrapper struct {
+	forwardRef bool
+	refType    string
+	propsType  string
+}
+
+// Aplicar a un componente la información de sus envoltorios
+func (w componentWrapper) apply(decl *componentDecl) *componentDecl {
+	if w.forwardRef {
+		decl.forwardRef, decl.refType = true, w.refType
+	}
+	if w.propsType != "" && decl.propsType == "" {
+		decl.propsType = w.propsType
+	}
+	return decl
+}
+
+// Desenvolver memo(...) y forwardRef(...) hasta la función o el identificador
+// del componente
+func unwrapComponent(n node) (node, componentWrapper) {
+	var w componentWrapper
+	for {
+		n = unparen(n)
+		call, ok := n.(*callExpr)
+		if !ok || len(call.args) == 0 {
+			return n, w
+		}
+		var name string
+		switch callee := call.callee.(type) {
+		case *ident:
+			name = callee.name
+		case *memberExpr:
+			if obj, ok := callee.object.(*ident); ok && obj.name == "React" {
+				name = callee.property
+			}
+		}
+		var typeArgs []string
+		if call.typeArgs != "" {
+			typeArgs = splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(call.typeArgs, "<"), ">"), ',')
+		}
+		switch name {
+		case "memo":
+			if len(typeArgs) > 0 && w.propsType == "" {
+				w.propsType = strings.TrimSpace(typeArgs[0])
+			}
+		case "forwardRef":
+			w.forwardRef = true
+			if len(typeArgs) > 0 {
+				w.refType = strings.TrimSpace(typeArgs[0])
+			}
+			if len(typeArgs) > 1 && w.propsType == "" {
+				w.propsType = strings.TrimSpace(typeArgs[1])
+			}
+		default:
+			return n, w
+		}
+		n = call.args[0]
+	}
+}
+
+// Componente declarado como variable: const Card = (props) => ...,
+// const Card: React.FC<CardProps> = ..., const Card = memo(...)
+func componentDeclarator(v *declarator, stmt node) (*componentDecl, bool) {
+	id, ok := v.target.(*ident)
+	if !ok || !isComponentName(id.name) {
+		return nil, false
+	}
+	inner, wrapper := unwrapComponent(v.init)
+	fn, ok := inner.(*function)
+	if !ok {
+		return nil, false
+	}
+	decl := &componentDecl{name: id.name, fn: fn, stmt: stmt, propsType: functionComponentProps(v.typ)}
+	return wrapper.apply(decl), true
+}
+
+// Tipo de las props de React.FC<P> y equivalentes
+func functionComponentProps(typ string) string {
+	for _, prefix := range []string{"React.FC<", "FC<", "React.FunctionComponent<", "FunctionComponent<", "React.VFC<", "VFC<"} {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(typ), prefix); ok {
+			return strings.TrimSpace(strings.TrimSuffix(rest, ">"))
+		}
+	}
+	return ""
+}
+
+// Nombre del componente de export default Card o export default memo(Card)
+func exportedComponent(exp *exportDecl) (string, bool) {
+	if exp.expr == nil {
+		return "", false
+	}
+	inner, _ := unwrapComponent(exp.expr)
+	id, ok := inner.(*ident)
+	if !ok {
+		return "", false
+	}
+	return id.name, true
+}
+
+// Componente.displayName = '...'
+func isDisplayName(stmt *exprStmt) bool {
+	assign, ok := stmt.expr.(*assignExpr)
+	if !ok {
+		return false
+	}
+	member, ok := assign.target.(*memberExpr)
+	return ok && member.property == "displayName"
+}
+
+// Otro componente del mismo archivo
+type sibling struct {
+	decl    *componentDecl
+	snippet bool // se emite como {#snippet} en el componente principal
+}
+
+// Componentes del archivo distintos del principal, en orden de aparición
+func (c *converter) otherComponents(main *componentDecl) []*componentDecl {
+	var others []*componentDecl
+	for _, stmt := range c.file.body {
+		switch d := unwrapExport(stmt).(type) {
+		case *funcDecl:
+			if d.fn != main.fn && isComponentName(d.name) && returnsJSX(d.fn) {
+				others = append(others, &componentDecl{name: d.name, fn: d.fn, stmt: stmt})
+			}
+		case *varDecl:
+			for _, v := range d.decls {
+				if decl, ok := componentDeclarator(v, stmt); ok && decl.fn != main.fn && returnsJSX(decl.fn) {
+					others = append(others, decl)
+				}
+			}
+		}
+	}
+	return others
+}
+
+// Localizar los demás componentes del archivo y decidir cuáles se emiten
+// como snippet del principal
+func (c *converter) findSiblings(main *componentDecl) {
+	others := c.otherComponents(main)
+	if len(others) == 0 {
+		return
+	}
+	c.siblings = make(map[string]*sibling)
+	for _, decl := range others {
+		c.siblings[decl.name] = &sibling{decl: decl, snippet: c.snippetCandidate(decl, main)}
+	}
+}
+
+// Demás componentes del archivo en orden de aparición
+func (c *converter) siblingList() []*sibling {
+	var list []*sibling
+	for _, s := range c.siblings {
+		list = append(list, s)
+	}
+	slices.SortFunc(list, func(a, b *sibling) int {
+		return a.decl.fn.start - b.decl.fn.start
+	})
+	return list
+}
+
+// Un componente es un snippet si es pequeño, no se exporta, no usa hooks ni
+// children, solo devuelve JSX y únicamente lo usa el componente principal,
+// sin hijos
+func (c *converter) snippetCandidate(decl, main *componentDecl) bool {
+	if _, exported := decl.stmt.(*exportDecl); exported {
+		return false
+	}
+	fn := decl.fn
+	if strings.Count(nodeText(c.src, fn), "\n") >= snippetMaxLines || len(fn.params) > 1 {
+		return false
+	}
+	if len(fn.params) == 1 {
+		pat, ok := fn.params[0].target.(*objectPattern)
+		if !ok || slices.ContainsFunc(pat.props, func(p *bindingProp) bool { return p.rest }) {
+			return false
+		}
+	}
+	if fn.body != nil {
+		if len(fn.body.body) != 1 {
+			return false
+		}
+		if ret, ok := fn.body.body[0].(*returnStmt); !ok || !isJSX(ret.arg) {
+			return false
+		}
+	}
+
+	simple := true
+	walk(fn, func(n node) bool {
+		switch n := n.(type) {
+		case *hookCall:
+			simple = false
+		case *ident:
+			if n.name == "children" {
+				simple = false
+			}
+		}
+		return simple
+	})
+	if !simple {
+		return false
+	}
+
+	// Solo se usa como <Componente /> sin hijos dentro del principal
+	used := false
+	walk(c.file, func(n node) bool {
+		if n == decl.stmt {
+			return false
+		}
+		switch n := n.(type) {
+		case *ident:
+			// Referencias como valor, o export { Componente }
+			if n.name == decl.name {
+				simple = false
+			}
+		case *exportDecl:
+			if slices.Contains(n.names, decl.name) {
+				simple = false
+			}
+		case *jsxElement:
+			if n.name == decl.name {
+				used = true
+				if len(n.children) > 0 || !within(n, main.fn) {
+					simple = false
+				}
+			}
+		}
+		return simple
+	})
+	return simple && used
+}
+
+// Indica si el nodo está dentro de otro
+func within(n, outer node) bool {
+	start, end := n.bounds()
+	outerStart, outerEnd := outer.bounds()
+	return start >= outerStart && end <= outerEnd
+}
+
+// Marcar como convertidos los demás componentes del archivo y sus exports,
+// que no forman parte del script del componente actual
+func (c *converter) consumeSiblings() {
+	for _, s := range c.siblings {
+		if s.decl == c.decl {
+			continue
+		}
+		c.consumed[s.decl.stmt] = true
+		if vd, ok := unwrapExport(s.decl.stmt).(*varDecl); ok {
+			for _, d := range vd.decls {
+				c.consumed[d] = true
+			}
+		}
+		// El tipo de sus props se resuelve en su propio archivo
+		if len(s.decl.fn.params) > 0 && s.decl.fn.params[0].typ != "" {
+			c.typeMembers(s.decl.fn.params[0].typ, 0)
+		}
+	}
+	if c.main != nil && c.main != c.decl {
+		c.consumed[c.main.stmt] = true
+		if vd, ok := unwrapExport(c.main.stmt).(*varDecl); ok {
+			for _, d := range vd.decls {
+				c.consumed[d] = true
+			}
+		}
+		if len(c.main.fn.params) > 0 && c.main.fn.params[0].typ != "" {
+			c.typeMembers(c.main.fn.params[0].typ, 0)
+		}
+	}
+
+	for _, stmt := range c.file.body {
+		exp, ok := stmt.(*exportDecl)
+		if !ok || exp.decl != nil || exp.source != "" {
+			continue
+		}
+		if name, ok := exportedComponent(exp); ok && c.isComponent(name) {
+			c.consumed[stmt] = true
+		}
+		if exp.expr == nil && len(exp.names) > 0 && !slices.ContainsFunc(exp.names, func(name string) bool { return !c.isComponent(name) }) {
+			c.consumed[stmt] = true
+		}
+	}
+}
+
+// Indica si el nombre es uno de los componentes del archivo
+func (c *converter) isComponent(name string) bool {
+	return c.siblings[name] != nil || (c.main != nil && c.main.name == name)
+}
+
+// Imports de los componentes del mismo archivo que se escriben en su propio
+// .svelte y que usa el componente actual
+func (c *converter) siblingImports(component *ReactComponent) []string {
+	if c.decl == nil || len(c.siblings) == 0 {
+		return nil
+	}
+	// Los snippets se añaden al markup del principal, así que sus
+	// componentes también se importan en él
+	bodies := []node{c.decl.fn}
+	if c.decl == c.main {
+		for _, s := range c.siblingList() {
+			if s.snippet {
+				bodies = append(bodies, s.decl.fn)
+			}
+		}
+	}
+	used := make(map[string]bool)
+	for _, body := range bodies {
+		walk(body, func(n node) bool {
+			if el, ok := n.(*jsxElement); ok {
+				if s := c.siblings[el.name]; s != nil && !s.snippet && s.decl != c.decl {
+					used[el.name] = true
+				}
+			}
+			return true
+		})
+	}
+	var imports []string
+	for name := range used {
+		imports = append(imports, fmt.Sprintf("import %s from './%s.svelte'", name, name))
+	}
+
+	// El componente principal, si lo usa otro componente del archivo, y lo
+	// que este importa de su <script module>
+	if c.isSibling() {
+		usesMain := false
+		walk(c.decl.fn, func(n node) bool {
+			if el, ok := n.(*jsxElement); ok && el.name == c.main.name {
+				usesMain = true
+			}
+			return true
+		})
+		shared := c.sharedImports(component)
+		c.importsShared = len(shared) > 0
+		var clause []string
+		if usesMain {
+			clause = append(clause, c.main.name)
+		}
+		if len(shared) > 0 {
+			clause = append(clause, "{ "+strings.Join(shared, ", ")+" }")
+		}
+		if len(clause) > 0 {
+			imports = append(imports, fmt.Sprintf("import %s from './%s.svelte'", strings.Join(clause, ", "), c.main.name))
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// Nombres del <script module> del principal que usa el componente: los
+// valores a los que se refiere, getX/setX de los contextos que lee o provee
+// y los tipos que aparecen en su código o en el de sus props
+func (c *converter) sharedImports(component *ReactComponent) []string {
+	used := make(map[string]bool)
+	use := func(name string, kinds ...string) {
+		if slices.Contains(kinds, c.shared[name]) {
+			used[name] = true
+		}
+	}
+	contextArgs := make(map[node]bool)
+	walk(c.decl.fn, func(n node) bool {
+		switch n := n.(type) {
+		case *hookCall:
+			// useContext(X) se convierte en getX()
+			if n.hook == "useContext" && len(n.args) > 0 {
+				if id, ok := unparen(n.args[0]).(*ident); ok && c.contexts[id.name] != nil {
+					use(contextGetter(c.contexts[id.name].name), "value")
+					contextArgs[id] = true
+				}
+			}
+		case *jsxElement:
+			if ref, ok := c.providerContext(n); ok {
+				use(contextSetter(ref.name), "value")
+			} else {
+				use(strings.Split(n.name, ".")[0], "value", "enum")
+			}
+		case *ident:
+			if contextArgs[n] || c.scopes.declared[n] {
+				break
+			}
+			if found := c.scopes.at[n].lookup(n.name); found != nil && found.parent == nil {
+				use(n.name, "value", "enum")
+			}
+		}
+		return true
+	})
+
+	// Los tipos son texto, no nodos del árbol
+	text := []string{nodeText(c.src, c.decl.fn), component.PropsExtends}
+	for _, prop := range component.Props {
+		text = append(text, prop.Type)
+	}
+	words := strings.FieldsFunc(strings.Join(text, " "), func(r rune) bool { return !isIdentPart(r) })
+	for _, word := range words {
+		use(word, "type", "enum")
+	}
+
+	var names []string
+	for name := range used {
+		if c.shared[name] == "type" {
+			name = "type " + name
+		}
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.TrimPrefix(a, "type "), strings.TrimPrefix(b, "type "))
+	})
+	return names
+}
+
+// <Badge label="Nuevo" /> -> {@render Badge({ label: "Nuevo" })}. key no
+// llega al componente en React, así que tampoco se pasa al snippet
+func (c *converter) renderSnippet(el *jsxElement) string {
+	var args []string
+	for _, a := range el.attrs {
+		if c.skip[a] {
+			continue
+		}
+		switch attr := a.(type) {
+		case *jsxSpreadAttr:
+			args = append(args, "..."+c.print(attr.arg))
+		case *jsxAttr:
+			if attr.name == "key" {
+				continue
+			}
+			value := "true"
+			if container, ok := attr.value.(*jsxExprContainer); ok && container.expr != nil {
+				value = c.print(container.expr)
+			} else if attr.value != nil {
+				value = c.print(attr.value)
+			}
+			key := attr.name
+			if strings.Contains(key, "-") {
+				key = "'" + key + "'"
+			}
+			args = append(args, key+": "+value)
+		}
+	}
+	if len(args) == 0 {
+		return "{@render " + el.name + "()}"
+	}
+	return "{@render " + el.name + "({ " + strings.Join(args, ", ") + " })}"
+}
+
+// Bloque {#snippet} equivalente a un componente pequeño
+func snippetBlock(name string, props []PropDefinition, markup string) string {
+	var params string
+	if len(props) > 0 {
+		names := make([]string, len(props))
+		types := make([]string, len(props))
+		for i, prop := range props {
+			names[i] = prop.Name
+			if prop.DefaultValue != "" {
+				names[i] += " = " + prop.DefaultValue
+			}
+			optional := ""
+			if prop.Optional {
+				optional = "?"
+			}
+			types[i] = prop.Name + optional + ": " + prop.Type
+		}
+		params = fmt.Sprintf("{ %s }: { %s }", strings.Join(names, ", "), strings.Join(types, "; "))
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("{#snippet %s(%s)}\n", name, params))
+	writeIndented(&result, markup, "  ")
+	result.WriteString("{/snippet}")
+	return result.String()
+}
@@ -0,0 +1,381 @@
+package transpiler
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Los contextos de React se convierten en una clave exportada junto con dos
+// funciones, setX y getX, que envuelven setContext/getContext de Svelte. Los
+// componentes que leen o proveen un contexto importan esas funciones del
+// mismo módulo del que importaban el contexto.
+
+// <X.Provider value={...}> del componente
+type provider struct {
+	ref *contextRef
+	el  *jsxElement
+}
+
+// Contexto usado en el archivo
+type contextRef struct {
+	name     string      // nombre con el que se exporta, base de getX/setX
+	local    bool        // creado en este archivo con createContext
+	source   *importDecl // import del que procede, si no es local
+	get, set bool        // funciones que necesita el componente
+}
+
+// Funciones generadas para un contexto
+func contextGetter(name string) string { return "get" + name }
+func contextSetter(name string) string { return "set" + name }
+
+// Indica si el contexto tiene funciones getX/setX disponibles
+func (r *contextRef) helpers() bool {
+	return r.local || r.source != nil
+}
+
+// Localizar los contextos creados, leídos o provistos en el archivo
+func (c *converter) findContexts() {
+	for _, stmt := range c.file.body {
+		vd, ok := unwrapExport(stmt).(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			id, ok := d.target.(*ident)
+			if _, isCreate := createContextCall(d.init); ok && isCreate {
+				c.contexts[id.name] = &contextRef{name: id.name, local: true}
+			}
+		}
+	}
+
+	use := func(name string) *contextRef {
+		if ref, ok := c.contexts[name]; ok {
+			return ref
+		}
+		ref := &contextRef{name: name}
+		for _, stmt := range c.file.body {
+			imp, ok := stmt.(*importDecl)
+			if !ok {
+				continue
+			}
+			if imp.defaultName == name {
+				ref.source = imp
+			}
+			for _, spec := range imp.named {
+				if spec.local == name {
+					ref.source = imp
+					ref.name = spec.imported
+				}
+			}
+		}
+		c.contexts[name] = ref
+		return ref
+	}
+
+	// Lecturas con useContext
+	var elements []*jsxElement
+	walk(c.file, func(n node) bool {
+		switch n := n.(type) {
+		case *hookCall:
+			if n.hook == "useContext" && len(n.args) > 0 {
+				if id, ok := unparen(n.args[0]).(*ident); ok {
+					use(id.name).get = true
+				}
+			}
+		case *jsxElement:
+			elements = append(elements, n)
+		}
+		return true
+	})
+
+	// <X.Provider> y <X> (React 19) de contextos conocidos o con nombre XContext;
+	// otros X.Provider son componentes normales
+	for _, el := range elements {
+		name, provider := strings.CutSuffix(el.name, ".Provider")
+		if strings.Contains(name, ".") {
+			continue
+		}
+		if _, known := c.contexts[name]; known || (provider && strings.HasSuffix(name, "Context")) {
+			use(name).set = true
+		}
+	}
+}
+
+// createContext(valor) o React.createContext(valor)
+func createContextCall(n node) (*callExpr, bool) {
+	call, ok := unparen(n).(*callExpr)
+	if !ok {
+		return nil, false
+	}
+	switch callee := call.callee.(type) {
+	case *ident:
+		return call, callee.name == "createContext"
+	case *memberExpr:
+		obj, ok := callee.object.(*ident)
+		return call, ok && obj.name == "React" && callee.property == "createContext"
+	}
+	return nil, false
+}
+
+// Elemento <X.Provider value={...}> o <X value={...}> de un contexto conocido
+func (c *converter) providerContext(el *jsxElement) (*contextRef, bool) {
+	name := strings.TrimSuffix(el.name, ".Provider")
+	ref, ok := c.contexts[name]
+	return ref, ok && ref.set
+}
+
+// Extraer los contextos creados en el archivo y las lecturas y escrituras de
+// contexto del componente
+func (c *converter) extractContexts(decl *componentDecl, body []node) ([]ContextDefinition, []ContextUsage) {
+	var defs []ContextDefinition
+	var usages []ContextUsage
+
+	// 1. const ThemeContext = createContext<Theme>(valorPorDefecto); los
+	// demás componentes del archivo los importan del principal
+	for _, stmt := range c.file.body {
+		vd, ok := unwrapExport(stmt).(*varDecl)
+		if !ok || c.isSibling() {
+			continue
+		}
+		for _, d := range vd.decls {
+			id, ok := d.target.(*ident)
+			call, isCreate := createContextCall(d.init)
+			if !ok || !isCreate {
+				continue
+			}
+			c.consumed[d] = true
+			def := ContextDefinition{Name: id.name, Type: "any"}
+			if call.typeArgs != "" {
+				def.Type = strings.TrimSuffix(strings.TrimPrefix(call.typeArgs, "<"), ">")
+			}
+			if len(call.args) > 0 && !isEmptyValue(call.args[0]) {
+				def.DefaultValue = c.print(call.args[0])
+			}
+			defs = append(defs, def)
+		}
+	}
+
+	// export default ThemeContext
+	for _, stmt := range c.file.body {
+		exp, ok := stmt.(*exportDecl)
+		if !ok || !exp.isDefault {
+			continue
+		}
+		if id, ok := unparen(exp.expr).(*ident); ok {
+			for i := range defs {
+				if defs[i].Name == id.name {
+					defs[i].ExportDefault = true
+					c.consumed[stmt] = true
+				}
+			}
+		}
+	}
+
+	// 2. const theme = useContext(ThemeContext)
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			hook := firstHook(d.init)
+			if hook == nil || hook.hook != "useContext" {
+				continue
+			}
+			c.consumed[d] = true
+			usage := ContextUsage{Kind: vd.kind, Target: c.print(d.target), Expression: c.print(d.init), Order: c.order(d)}
+
+			// const { user } = useContext(AuthContext): las variables se leen
+			// del objeto para no perder los getters del Provider
+			id, isIdent := unparen(hook.args[0]).(*ident)
+			if _, isPattern := d.target.(*objectPattern); isPattern && isIdent && unparen(d.init) == node(hook) {
+				object := c.objectName(strings.TrimSuffix(id.name, "Context"))
+				if renames, ok := bindingAccesses(d.target, object); ok {
+					maps.Copy(c.renames, renames)
+					usage.Kind, usage.Target = "const", object
+				}
+			}
+			usages = append(usages, usage)
+		}
+	}
+
+	// 3. <ThemeContext.Provider value={theme}>: se convierte en setContext
+	// una vez conocidos los valores reactivos, en providerUsages. setContext
+	// se ejecuta una vez al crear el componente y vale para todos sus
+	// descendientes, así que un Provider condicional o repetido cambia de
+	// significado
+	if decl != nil {
+		provided := make(map[*contextRef]bool)
+		for _, kid := range children(decl.fn) {
+			walkConditional(kid, false, func(n node, conditional bool) {
+				el, ok := n.(*jsxElement)
+				if !ok {
+					return
+				}
+				ref, ok := c.providerContext(el)
+				if !ok {
+					return
+				}
+				switch {
+				case provided[ref]:
+					c.warn(el, CodeContextProvider, "el contexto %s se provee más de una vez; setContext solo conserva el último valor para todos los descendientes", ref.name)
+				case conditional:
+					c.warn(el, CodeContextProvider, "el Provider de %s está dentro de una condición, un bucle o una función; setContext se ejecuta siempre al crear el componente", ref.name)
+				}
+				provided[ref] = true
+				c.providers = append(c.providers, provider{ref: ref, el: el})
+			})
+		}
+	}
+
+	return defs, usages
+}
+
+// Recorrer el árbol indicando a fn si cada nodo solo se evalúa bajo una
+// condición, en un bucle o dentro de una función anidada
+func walkConditional(n node, conditional bool, fn func(n node, conditional bool)) {
+	fn(n, conditional)
+	switch n := n.(type) {
+	case *conditionalExpr:
+		walkConditional(n.test, conditional, fn)
+		walkConditional(n.cons, true, fn)
+		walkConditional(n.alt, true, fn)
+		return
+	case *binaryExpr:
+		if n.op == "&&" || n.op == "||" || n.op == "??" {
+			walkConditional(n.left, conditional, fn)
+			walkConditional(n.right, true, fn)
+			return
+		}
+	case *ifStmt:
+		walkConditional(n.test, conditional, fn)
+		walkConditional(n.cons, true, fn)
+		if n.alt != nil {
+			walkConditional(n.alt, true, fn)
+		}
+		return
+	case *function, *switchStmt, *forStmt, *forInStmt, *whileStmt, *doWhileStmt:
+		conditional = true
+	}
+	for _, kid := range children(n) {
+		walkConditional(kid, conditional, fn)
+	}
+}
+
+// Variables que reciben el valor de un contexto en el componente
+func contextTargets(body []node) []string {
+	var names []string
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			if hook := firstHook(d.init); hook != nil && hook.hook == "useContext" {
+				names = append(names, boundNames(d.target)...)
+			}
+		}
+	}
+	return names
+}
+
+// setContext de cada Provider. setContext se ejecuta una sola vez, así que
+// un objeto que lee props o states se pasa con getters para que los
+// componentes que lo consumen vean sus cambios
+func (c *converter) providerUsages(reactive map[string]bool) []ContextUsage {
+	var usages []ContextUsage
+	for _, p := range c.providers {
+		value := "undefined"
+		for _, a := range p.el.attrs {
+			attr, ok := a.(*jsxAttr)
+			if !ok || attr.name != "value" {
+				continue
+			}
+			container, ok := attr.value.(*jsxExprContainer)
+			if !ok || container.expr == nil {
+				if attr.value != nil {
+					value = c.print(attr.value)
+				}
+				continue
+			}
+			if obj, ok := unparen(container.expr).(*objectLit); ok {
+				value = c.getterObject(obj, reactive)
+				continue
+			}
+			value = c.print(container.expr)
+			if names := references(container.expr, reactive); len(names) > 0 {
+				c.warn(container.expr, CodeContextValue, "el valor del contexto lee %s y no se actualiza al cambiar; use un objeto con getters o $state", strings.Join(names, ", "))
+			}
+		}
+		usages = append(usages, ContextUsage{Expression: c.setContextCall(p.ref, value), Order: c.order(p.el)})
+	}
+	return usages
+}
+
+// Llamada que sustituye a useContext(X)
+func (c *converter) getContextCall(hook *hookCall) string {
+	arg := "undefined"
+	if len(hook.args) > 0 {
+		arg = c.print(hook.args[0])
+		if id, ok := unparen(hook.args[0]).(*ident); ok {
+			if ref := c.contexts[id.name]; ref != nil && ref.helpers() {
+				return contextGetter(ref.name) + "()"
+			}
+		}
+	}
+	c.svelteImports["getContext"] = true
+	return "getContext(" + arg + ")"
+}
+
+func (c *converter) setContextCall(ref *contextRef, value string) string {
+	if ref.helpers() {
+		return contextSetter(ref.name) + "(" + value + ")"
+	}
+	c.svelteImports["setContext"] = true
+	return "setContext(" + ref.name + ", " + value + ")"
+}
+
+// Import con las funciones de contexto que necesita el componente añadidas;
+// devuelve false si el import no cambia
+func (c *converter) contextImport(imp *importDecl) (string, bool) {
+	var extra []string
+	for _, ref := range c.contexts {
+		if ref.source != imp {
+			continue
+		}
+		if ref.get {
+			extra = append(extra, contextGetter(ref.name))
+		}
+		if ref.set {
+			extra = append(extra, contextSetter(ref.name))
+		}
+	}
+	if len(extra) == 0 {
+		return "", false
+	}
+
+	var clause []string
+	if imp.defaultName != "" {
+		clause = append(clause, imp.defaultName)
+	}
+	var named []string
+	for _, spec := range imp.named {
+		text := spec.imported
+		if spec.local != spec.imported {
+			text += " as " + spec.local
+		}
+		if spec.typeOnly {
+			text = "type " + text
+		}
+		named = append(named, text)
+	}
+	slices.Sort(extra)
+	named = append(named, extra...)
+	clause = append(clause, "{ "+strings.Join(named, ", ")+" }")
+
+	text := strings.TrimSpace(nodeText(c.src, imp))
+	from := strings.LastIndex(text, "from")
+	return fmt.Sprintf("import %s %s", strings.Join(clause, ", "), strings.TrimSuffix(text[from:], ";")), true
+}
@@ -0,0 +1,161 @@
+package transpiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Gravedad de un diagnóstico
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "info"
+}
+
+// Códigos estables de diagnóstico, pensados para agrupar y filtrar
+const (
+	CodeSyntaxError          = "syntax-error"
+	CodeSetterUpdater        = "setter-updater"
+	CodeJSXAttributeNode     = "jsx-attribute-element"
+	CodeUnconvertedStatement = "unconverted-statement"
+	CodeUnsupportedHook      = "unsupported-hook"
+	CodeUnhandledJSX         = "unhandled-jsx-expression"
+	CodeJSXInScript          = "jsx-in-script"
+	CodeRefCallback          = "ref-callback"
+	CodeCallbackDependency   = "callback-dependency"
+	CodeContextValue         = "context-value"
+	CodeHookReturn           = "hook-return"
+	CodeStyleObject          = "style-object"
+	CodeEarlyReturn          = "early-return"
+	CodeContextProvider      = "context-provider"
+	CodeEventCollision       = "event-collision"
+)
+
+// Diagnóstico asociado a una posición del código fuente. Line y Column
+// empiezan en 1; Column se cuenta en caracteres, no en bytes.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Message  string
+	File     string
+	Line     int
+	Column   int
+	Snippet  string // línea de código donde se produjo
+}
+
+// Formato file:line:col: severity: message (code)
+func (d Diagnostic) Error() string {
+	location := fmt.Sprintf("%d:%d", d.Line, d.Column)
+	if d.File != "" {
+		location = d.File + ":" + location
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", location, d.Severity, d.Message, d.Code)
+}
+
+// Línea de código con un marcador bajo la columna del diagnóstico
+func (d Diagnostic) Excerpt() string {
+	if d.Snippet == "" {
+		return ""
+	}
+	var marker strings.Builder
+	for i, r := range []rune(d.Snippet) {
+		if i >= d.Column-1 {
+			break
+		}
+		if r == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteRune(' ')
+		}
+	}
+	return d.Snippet + "\n" + marker.String() + "^"
+}
+
+// Resultado de una transpilación: el código Svelte y los diagnósticos
+type Result struct {
+	Code        string
+	Extension   string // extensión del archivo generado: .svelte, .ts o .svelte.ts
+	Component   string // componente principal; vacío si el archivo no declara ninguno
+	Files       []File // otros componentes del archivo, que se escriben junto al principal
+	Diagnostics []Diagnostic
+}
+
+// Archivo adicional generado a partir del mismo código fuente
+type File struct {
+	Name string // nombre del archivo, sin directorio: Badge.svelte
+	Code string
+}
+
+// Indica si algún diagnóstico es un error
+func (r *Result) HasErrors() bool {
+	for _, d := range r.Diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Crear un diagnóstico para la posición pos (offset en bytes) de src
+func newDiagnostic(file, src string, pos int, severity Severity, code, message string) Diagnostic {
+	pos = max(0, min(pos, len(src)))
+	lineStart := strings.LastIndexByte(src[:pos], '\n') + 1
+	lineEnd := strings.IndexByte(src[pos:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(src)
+	} else {
+		lineEnd += pos
+	}
+
+	return Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  message,
+		File:     file,
+		Line:     strings.Count(src[:pos], "\n") + 1,
+		Column:   utf8.RuneCountInString(src[lineStart:pos]) + 1,
+		Snippet:  strings.TrimRight(src[lineStart:lineEnd], " \t\r"),
+	}
+}
+
+// Ordenar los diagnósticos por posición
+func sortDiagnostics(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+}
+
+// Registrar un aviso sobre el nodo n, junto con su marcador TODO
+func (c *converter) warn(n node, code, format string, args ...any) {
+	c.diagnose(n, SeverityWarning, code, fmt.Sprintf(format, args...))
+}
+
+// Registrar un error sobre el nodo n: el resultado no es equivalente al
+// código original
+func (c *converter) fail(n node, code, format string, args ...any) {
+	c.diagnose(n, SeverityError, code, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) diagnose(n node, severity Severity, code, message string) {
+	start, _ := n.bounds()
+	diag := newDiagnostic(c.filename, c.src, start, severity, code, message)
+	c.diagnostics = append(c.diagnostics, diag)
+	c.todos = append(c.todos, TodoDefinition{Line: diag.Line, Message: diag.Message})
+}
@@ -0,0 +1,253 @@
+package transpiler
+
+import (
+	"strings"
+)
+
+// Nombres de los atributos y eventos de React DOM y su equivalente en Svelte.
+// Solo se aplican a los atributos de elementos HTML y SVG: las props de los
+// componentes conservan su nombre.
+
+// Atributos HTML que React escribe en camelCase
+var htmlAttributes = map[string]string{
+	"className": "class", "htmlFor": "for", "tabIndex": "tabindex", "readOnly": "readonly",
+	"autoFocus": "autofocus", "autoComplete": "autocomplete", "autoCapitalize": "autocapitalize",
+	"autoCorrect": "autocorrect", "autoPlay": "autoplay", "autoSave": "autosave",
+	"maxLength": "maxlength", "minLength": "minlength", "contentEditable": "contenteditable",
+	"spellCheck": "spellcheck", "crossOrigin": "crossorigin", "dateTime": "datetime",
+	"encType": "enctype", "formAction": "formaction", "formEncType": "formenctype",
+	"formMethod": "formmethod", "formNoValidate": "formnovalidate", "formTarget": "formtarget",
+	"frameBorder": "frameborder", "hrefLang": "hreflang", "httpEquiv": "http-equiv",
+	"inputMode": "inputmode", "noValidate": "novalidate", "accessKey": "accesskey",
+	"allowFullScreen": "allowfullscreen", "cellPadding": "cellpadding", "cellSpacing": "cellspacing",
+	"charSet": "charset", "colSpan": "colspan", "rowSpan": "rowspan", "srcSet": "srcset",
+	"srcDoc": "srcdoc", "srcLang": "srclang", "useMap": "usemap", "enterKeyHint": "enterkeyhint",
+	"referrerPolicy": "referrerpolicy", "playsInline": "playsinline", "marginHeight": "marginheight",
+	"marginWidth": "marginwidth", "acceptCharset": "accept-charset", "classID": "classid",
+	"contextMenu": "contextmenu", "controlsList": "controlslist", "disablePictureInPicture": "disablepictureinpicture",
+	"disableRemotePlayback": "disableremoteplayback", "fetchPriority": "fetchpriority",
+	"itemProp": "itemprop", "itemScope": "itemscope", "itemType": "itemtype", "itemID": "itemid",
+	"itemRef": "itemref", "popoverTarget": "popovertarget", "popoverTargetAction": "popovertargetaction",
+	"mediaGroup": "mediagroup", "keyParams": "keyparams", "keyType": "keytype",
+	"radioGroup": "radiogroup", "noModule": "nomodule",
+	// Valores iniciales de los campos no controlados
+	"defaultValue": "value", "defaultChecked": "checked",
+}
+
+// Atributos SVG que React escribe en camelCase y en el DOM llevan guiones o
+// un espacio de nombres. Los que son camelCase también en SVG (viewBox,
+// gradientUnits...) no cambian.
+var svgAttributes = map[string]string{
+	"accentHeight": "accent-height", "alignmentBaseline": "alignment-baseline",
+	"arabicForm": "arabic-form", "baselineShift": "baseline-shift", "capHeight": "cap-height",
+	"clipPath": "clip-path", "clipRule": "clip-rule", "colorInterpolation": "color-interpolation",
+	"colorInterpolationFilters": "color-interpolation-filters", "colorProfile": "color-profile",
+	"colorRendering": "color-rendering", "dominantBaseline": "dominant-baseline",
+	"enableBackground": "enable-background", "fillOpacity": "fill-opacity", "fillRule": "fill-rule",
+	"floodColor": "flood-color", "floodOpacity": "flood-opacity", "fontFamily": "font-family",
+	"fontSize": "font-size", "fontSizeAdjust": "font-size-adjust", "fontStretch": "font-stretch",
+	"fontStyle": "font-style", "fontVariant": "font-variant", "fontWeight": "font-weight",
+	"glyphName": "glyph-name", "glyphOrientationHorizontal": "glyph-orientation-horizontal",
+	"glyphOrientationVertical": "glyph-orientation-vertical", "horizAdvX": "horiz-adv-x",
+	"horizOriginX": "horiz-origin-x", "imageRendering": "image-rendering",
+	"letterSpacing": "letter-spacing", "lightingColor": "lighting-color",
+	"markerEnd": "marker-end", "markerMid": "marker-mid", "markerStart": "marker-start",
+	"overlinePosition": "overline-position", "overlineThickness": "overline-thickness",
+	"paintOrder": "paint-order", "panose1": "panose-1", "pointerEvents": "pointer-events",
+	"renderingIntent": "rendering-intent", "shapeRendering": "shape-rendering",
+	"stopColor": "stop-color", "stopOpacity": "stop-opacity",
+	"strikethroughPosition": "strikethrough-position", "strikethroughThickness": "strikethrough-thickness",
+	"strokeDasharray": "stroke-dasharray", "strokeDashoffset": "stroke-dashoffset",
+	"strokeLinecap": "stroke-linecap", "strokeLinejoin": "stroke-linejoin",
+	"strokeMiterlimit": "stroke-miterlimit", "strokeOpacity": "stroke-opacity",
+	"strokeWidth": "stroke-width", "textAnchor": "text-anchor", "textDecoration": "text-decoration",
+	"textRendering": "text-rendering", "transformOrigin": "transform-origin",
+	"underlinePosition": "underline-position", "underlineThickness": "underline-thickness",
+	"unicodeBidi": "unicode-bidi", "unicodeRange": "unicode-range", "unitsPerEm": "units-per-em",
+	"vAlphabetic": "v-alphabetic", "vHanging": "v-hanging", "vIdeographic": "v-ideographic",
+	"vMathematical": "v-mathematical", "vectorEffect": "vector-effect", "vertAdvY": "vert-adv-y",
+	"vertOriginX": "vert-origin-x", "vertOriginY": "vert-origin-y", "wordSpacing": "word-spacing",
+	"writingMode": "writing-mode", "xHeight": "x-height",
+	"xlinkActuate": "xlink:actuate", "xlinkArcrole": "xlink:arcrole", "xlinkHref": "xlink:href",
+	"xlinkRole": "xlink:role", "xlinkShow": "xlink:show", "xlinkTitle": "xlink:title",
+	"xlinkType": "xlink:type", "xmlBase": "xml:base", "xmlLang": "xml:lang",
+	"xmlSpace": "xml:space", "xmlnsXlink": "xmlns:xlink",
+}
+
+// Atributos enumerados que React escribe como "true" cuando no llevan valor
+var enumeratedBooleans = map[string]bool{"draggable": true, "spellcheck": true, "contenteditable": true}
+
+// Props de React DOM sin equivalente en el markup, que se omiten
+var reactOnlyAttributes = map[string]bool{
+	"suppressHydrationWarning": true, "suppressContentEditableWarning": true,
+}
+
+// Eventos de React DOM. En Svelte el atributo es el nombre del evento del DOM
+// en minúsculas precedido de on; los que tienen otro nombre están en
+// renamedEvents
+var reactEvents = []string{
+	// Ratón y puntero
+	"onClick", "onContextMenu", "onDoubleClick", "onAuxClick", "onMouseDown", "onMouseUp",
+	"onMouseMove", "onMouseEnter", "onMouseLeave", "onMouseOver", "onMouseOut",
+	"onPointerDown", "onPointerUp", "onPointerMove", "onPointerEnter", "onPointerLeave",
+	"onPointerOver", "onPointerOut", "onPointerCancel", "onGotPointerCapture",
+	"onLostPointerCapture", "onWheel",
+	// Táctiles
+	"onTouchStart", "onTouchMove", "onTouchEnd", "onTouchCancel",
+	// Arrastrar y soltar
+	"onDrag", "onDragStart", "onDragEnd", "onDragEnter", "onDragLeave", "onDragOver",
+	"onDragExit", "onDrop",
+	// Teclado, foco y formularios
+	"onKeyDown", "onKeyUp", "onKeyPress", "onFocus", "onBlur", "onChange", "onInput",
+	"onBeforeInput", "onSubmit", "onReset", "onInvalid", "onSelect",
+	"onCompositionStart", "onCompositionUpdate", "onCompositionEnd",
+	// Portapapeles
+	"onCopy", "onCut", "onPaste",
+	// Desplazamiento, animaciones y transiciones
+	"onScroll", "onScrollEnd", "onAnimationStart", "onAnimationEnd", "onAnimationIteration",
+	"onTransitionStart", "onTransitionEnd", "onTransitionRun", "onTransitionCancel",
+	// Multimedia e imágenes
+	"onLoad", "onError", "onAbort", "onCanPlay", "onCanPlayThrough", "onDurationChange",
+	"onEmptied", "onEncrypted", "onEnded", "onLoadedData", "onLoadedMetadata", "onLoadStart",
+	"onPause", "onPlay", "onPlaying", "onProgress", "onRateChange", "onSeeked", "onSeeking",
+	"onStalled", "onSuspend", "onTimeUpdate", "onVolumeChange", "onWaiting",
+	// Otros
+	"onToggle", "onBeforeToggle", "onClose", "onCancel", "onResize",
+}
+
+// Eventos cuyo nombre en el DOM no es el de React en minúsculas
+var renamedEvents = map[string]string{
+	"onDoubleClick": "ondblclick",
+}
+
+// Tabla de eventos de React a atributos de Svelte
+var domEvents = func() map[string]string {
+	events := make(map[string]string, len(reactEvents))
+	for _, name := range reactEvents {
+		events[name] = strings.ToLower(name)
+	}
+	for name, event := range renamedEvents {
+		events[name] = event
+	}
+	return events
+}()
+
+// Inputs cuyo onChange de React corresponde al evento change del DOM; en el
+// resto, React lo dispara con cada cambio del valor, como input
+var changeInputs = map[string]bool{"checkbox": true, "radio": true, "file": true}
+
+// Nombre en Svelte de un atributo de React DOM; "" si se omite. Las props de
+// los componentes no cambian
+func (c *converter) attributeName(el *jsxElement, name string) string {
+	if el == nil || !isHTMLName(el.name) {
+		return name
+	}
+	if reactOnlyAttributes[name] {
+		return ""
+	}
+	if attr, ok := htmlAttributes[name]; ok {
+		return attr
+	}
+	if attr, ok := svgAttributes[name]; ok {
+		return attr
+	}
+
+	// onClickCapture -> onclickcapture
+	base, capture := name, false
+	if _, ok := domEvents[name]; !ok {
+		base, capture = strings.CutSuffix(name, "Capture")
+	}
+	event, ok := domEvents[base]
+	if !ok {
+		return name
+	}
+	if base == "onChange" && isTextField(el) && !changeCollides(el, name) {
+		event = "oninput"
+	}
+	if capture {
+		event += "capture"
+	}
+	return event
+}
+
+// Un onChange que se convertiría en oninput junto a un onInput del mismo
+// elemento: se conserva como el change nativo
+func changeCollides(el *jsxElement, name string) bool {
+	input := strings.Replace(name, "onChange", "onInput", 1)
+	for _, a := range el.attrs {
+		if attr, ok := a.(*jsxAttr); ok && attr.name == input {
+			return true
+		}
+	}
+	return false
+}
+
+// Avisar de los onChange de campos de texto que no pasan a oninput porque
+// el elemento ya tiene onInput
+func (c *converter) checkChangeEvents(el *jsxElement) {
+	if !isHTMLName(el.name) || !isTextField(el) {
+		return
+	}
+	for _, a := range el.attrs {
+		attr, ok := a.(*jsxAttr)
+		if !ok || c.skip[attr] || c.bindings[attr] != "" {
+			continue
+		}
+		if (attr.name == "onChange" || attr.name == "onChangeCapture") && changeCollides(el, attr.name) {
+			c.warn(attr, CodeEventCollision, "%s se convierte en el evento change nativo, que solo se dispara al confirmar el valor, porque el elemento ya tiene onInput", attr.name)
+		}
+	}
+}
+
+// Campos en los que el onChange de React se dispara al escribir
+func isTextField(el *jsxElement) bool {
+	switch el.name {
+	case "textarea":
+		return true
+	case "input":
+		for _, a := range el.attrs {
+			attr, ok := a.(*jsxAttr)
+			if !ok || attr.name != "type" {
+				continue
+			}
+			lit, ok := attr.value.(*literal)
+			return !ok || lit.kind != litString || !changeInputs[lit.raw[1:len(lit.raw)-1]]
+		}
+		return true
+	}
+	return false
+}
+
+// Omitir las props exclusivas de React; dangerouslySetInnerHTML={{ __html: x }}
+// se devuelve para emitirlo como {@html x}
+func (c *converter) dropAttributes(el *jsxElement) node {
+	if !isHTMLName(el.name) {
+		return nil
+	}
+	var html node
+	for _, a := range el.attrs {
+		attr, ok := a.(*jsxAttr)
+		if !ok {
+			continue
+		}
+		if reactOnlyAttributes[attr.name] {
+			c.skip[attr] = true
+		}
+		if attr.name != "dangerouslySetInnerHTML" {
+			continue
+		}
+		container, ok := attr.value.(*jsxExprContainer)
+		if !ok || container.expr == nil {
+			continue
+		}
+		obj, ok := unparen(container.expr).(*objectLit)
+		if !ok || len(obj.props) != 1 {
+			continue
+		}
+		if key, ok := obj.props[0].key.(*ident); ok && key.name == "__html" && !obj.props[0].computed {
+			c.skip[attr] = true
+			html = obj.props[0].value
+		}
+	}
+	return html
+}
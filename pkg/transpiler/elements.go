@@ -0,0 +1,171 @@
+package transpiler
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Las props que se reenvían a un elemento ({ title, ...rest } con
+// <button {...rest}>) se tipan con los atributos de svelte/elements en lugar
+// de los de React.
+
+// Elementos con un tipo de atributos propio en svelte/elements
+var elementAttributes = map[string]string{
+	"a": "HTMLAnchorAttributes", "area": "HTMLAreaAttributes", "audio": "HTMLAudioAttributes",
+	"base": "HTMLBaseAttributes", "blockquote": "HTMLQuoteAttributes", "button": "HTMLButtonAttributes",
+	"canvas": "HTMLCanvasAttributes", "col": "HTMLColAttributes", "colgroup": "HTMLColgroupAttributes",
+	"data": "HTMLDataAttributes", "del": "HTMLDelAttributes", "details": "HTMLDetailsAttributes",
+	"dialog": "HTMLDialogAttributes", "embed": "HTMLEmbedAttributes", "fieldset": "HTMLFieldsetAttributes",
+	"form": "HTMLFormAttributes", "iframe": "HTMLIframeAttributes", "img": "HTMLImgAttributes",
+	"input": "HTMLInputAttributes", "ins": "HTMLInsAttributes", "label": "HTMLLabelAttributes",
+	"li": "HTMLLiAttributes", "link": "HTMLLinkAttributes", "map": "HTMLMapAttributes",
+	"menu": "HTMLMenuAttributes", "meta": "HTMLMetaAttributes", "meter": "HTMLMeterAttributes",
+	"object": "HTMLObjectAttributes", "ol": "HTMLOlAttributes", "optgroup": "HTMLOptgroupAttributes",
+	"option": "HTMLOptionAttributes", "output": "HTMLOutputAttributes", "progress": "HTMLProgressAttributes",
+	"q": "HTMLQuoteAttributes", "select": "HTMLSelectAttributes", "source": "HTMLSourceAttributes",
+	"table": "HTMLTableAttributes", "td": "HTMLTdAttributes", "textarea": "HTMLTextareaAttributes",
+	"th": "HTMLThAttributes", "time": "HTMLTimeAttributes", "track": "HTMLTrackAttributes",
+	"video": "HTMLVideoAttributes", "svg": "SVGAttributes<SVGSVGElement>",
+}
+
+// Interfaces del DOM de los elementos que usan el HTMLAttributes genérico
+var domInterfaces = map[string]string{
+	"div": "HTMLDivElement", "span": "HTMLSpanElement", "p": "HTMLParagraphElement",
+	"ul": "HTMLUListElement", "pre": "HTMLPreElement", "hr": "HTMLHRElement",
+	"h1": "HTMLHeadingElement", "h2": "HTMLHeadingElement", "h3": "HTMLHeadingElement",
+	"h4": "HTMLHeadingElement", "h5": "HTMLHeadingElement", "h6": "HTMLHeadingElement",
+}
+
+// Tipo de svelte/elements con los atributos de un elemento HTML
+func elementAttributesType(tag string) string {
+	if typ, ok := elementAttributes[tag]; ok {
+		return typ
+	}
+	if iface, ok := domInterfaces[tag]; ok {
+		return "HTMLAttributes<" + iface + ">"
+	}
+	return "HTMLAttributes<HTMLElement>"
+}
+
+var (
+	// React.ButtonHTMLAttributes<HTMLButtonElement> -> HTMLButtonAttributes
+	reactElementAttributes = regexp.MustCompile(`\b(?:React\.)?([A-Z][a-z]+)HTMLAttributes<[^<>]*>`)
+	// React.HTMLAttributes<T> y React.SVGProps<T>
+	reactAttributes = regexp.MustCompile(`\b(?:React\.)?(HTMLAttributes|SVGAttributes|SVGProps)<`)
+	// React.ComponentProps<'button'>, ComponentPropsWithoutRef<'input'>...
+	reactComponentProps = regexp.MustCompile(`\b(?:React\.)?ComponentProps(?:WithoutRef|WithRef)?<\s*['"](\w+)['"]\s*>`)
+	// Nombres que se importan de svelte/elements
+	svelteElementTypes = regexp.MustCompile(`\b(?:HTML\w*Attributes|SVGAttributes)\b`)
+)
+
+// Sustituir los tipos de atributos de React por los de svelte/elements;
+// devuelve false si el tipo no contiene ninguno
+func svelteAttributesType(typ string) (string, bool) {
+	matched := false
+	for _, re := range []*regexp.Regexp{reactElementAttributes, reactAttributes, reactComponentProps} {
+		matched = matched || re.MatchString(typ)
+	}
+	converted := reactElementAttributes.ReplaceAllStringFunc(typ, func(m string) string {
+		name := reactElementAttributes.FindStringSubmatch(m)[1]
+		if name == "All" {
+			return "HTMLAttributes<HTMLElement>"
+		}
+		return "HTML" + name + "Attributes"
+	})
+	converted = reactAttributes.ReplaceAllStringFunc(converted, func(m string) string {
+		if strings.Contains(m, "HTML") {
+			return "HTMLAttributes<"
+		}
+		return "SVGAttributes<"
+	})
+	converted = reactComponentProps.ReplaceAllStringFunc(converted, func(m string) string {
+		return elementAttributesType(reactComponentProps.FindStringSubmatch(m)[1])
+	})
+	return converted, matched
+}
+
+// Tipos de atributos de los que heredan las props, resueltos a través de
+// las interfaces y los alias declarados en el archivo
+func (c *converter) attributeTypes(typ string, depth int) []string {
+	if depth > 8 {
+		return nil
+	}
+	var types []string
+	for _, part := range splitTopLevel(typ, '&') {
+		part = strings.TrimSpace(part)
+		if converted, ok := svelteAttributesType(part); ok {
+			types = append(types, converted)
+			continue
+		}
+		name := part
+		if i := strings.IndexByte(name, '<'); i != -1 {
+			name = name[:i]
+		}
+		for _, stmt := range c.file.body {
+			switch d := unwrapExport(stmt).(type) {
+			case *interfaceDecl:
+				if d.name == name {
+					for _, base := range splitTopLevel(d.extends, ',') {
+						types = append(types, c.attributeTypes(base, depth+1)...)
+					}
+				}
+			case *typeAlias:
+				if d.name == name && d.members == nil {
+					types = append(types, c.attributeTypes(d.typ, depth+1)...)
+				}
+			}
+		}
+	}
+	return types
+}
+
+// Tipo que extienden las props: los atributos de React del tipo declarado o,
+// si no los hay, los del elemento que recibe el resto de props
+func (c *converter) propsExtends(decl *componentDecl, props []PropDefinition) string {
+	if decl == nil {
+		return ""
+	}
+	typ := decl.propsType
+	if len(decl.fn.params) > 0 && decl.fn.params[0].typ != "" {
+		typ = decl.fn.params[0].typ
+	}
+	types := c.attributeTypes(typ, 0)
+
+	if len(types) == 0 {
+		i := slices.IndexFunc(props, func(p PropDefinition) bool { return p.Rest })
+		if i == -1 {
+			return ""
+		}
+		walk(decl.fn, func(n node) bool {
+			el, ok := n.(*jsxElement)
+			if !ok || !isHTMLName(el.name) || len(types) > 0 {
+				return len(types) == 0
+			}
+			for _, a := range el.attrs {
+				spread, ok := a.(*jsxSpreadAttr)
+				if !ok {
+					continue
+				}
+				if id, ok := unparen(spread.arg).(*ident); ok && id.name == props[i].Name {
+					types = append(types, elementAttributesType(el.name))
+				}
+			}
+			return true
+		})
+	}
+	if len(types) == 0 {
+		return ""
+	}
+	return strings.Join(types, " & ")
+}
+
+// import type { HTMLButtonAttributes } from 'svelte/elements'
+func elementsImport(extends string) string {
+	names := svelteElementTypes.FindAllString(extends, -1)
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	names = slices.Compact(names)
+	return "import type { " + strings.Join(names, ", ") + " } from 'svelte/elements'"
+}
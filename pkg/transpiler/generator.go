@@ -1,7 +1,9 @@
 package transpiler
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -9,24 +11,66 @@ import (
 func (t *Transpiler) generateSvelteCode(component *ReactComponent, processedJSX string) string {
 	var result strings.Builder
 
+	// Los contextos y el código del módulo que importan los demás componentes
+	// del archivo se exportan desde <script module>
+	script := component.Script
+	if component.Module {
+		result.WriteString("<script module lang=\"ts\">\n")
+		t.writeModuleScript(&result, component, "  ")
+		result.WriteString("</script>\n\n")
+	} else {
+		script.Functions = append(slices.Clone(component.ModuleFunctions), script.Functions...)
+	}
+
 	// Script tag
 	result.WriteString("<script lang=\"ts\">\n")
 
-	// Imports
-	if len(component.Imports) > 0 {
-		for _, imp := range component.Imports {
-			imp = strings.ReplaceAll(imp, ".jsx", ".svelte")
-			imp = strings.ReplaceAll(imp, ".tsx", ".svelte")
-			result.WriteString(fmt.Sprintf("  %s\n", imp))
+	if !component.Module {
+		// Imports
+		writeImports(&result, t.svelteImports(component), component.Imports, "  ")
+
+		// Tipos del módulo
+		for _, typ := range component.Types {
+			writeIndented(&result, typ, "  ")
+			result.WriteString("\n")
+		}
+
+		// Constantes del módulo, antes de las props que pueden usarlas como
+		// valor por defecto
+		for _, constant := range component.Constants {
+			writeIndented(&result, constant, "  ")
+			result.WriteString("\n")
+		}
+
+		// Hooks del archivo, convertidos como los de un módulo .svelte.ts
+		for _, hook := range component.Hooks {
+			t.writeHook(&result, hook, "", "  ")
 		}
-		result.WriteString("\n")
 	}
 
 	// Props
 	if len(component.Props) > 0 {
 		result.WriteString("  // Props\n")
-		result.WriteString("  type Props = {\n")
+		if component.PropsExtends != "" {
+			result.WriteString(fmt.Sprintf("  type Props = %s & {\n", component.PropsExtends))
+		} else {
+			result.WriteString("  type Props = {\n")
+		}
+		// Si el resto recibe todas las props declaradas, el tipo ya es completo
+		whole, typed := true, false
 		for _, prop := range component.Props {
+			whole = whole && (prop.Rest || prop.InRest)
+			typed = typed || prop.InRest
+		}
+		var rest string
+		for _, prop := range component.Props {
+			if prop.Rest {
+				rest = prop.Name
+				if component.PropsExtends == "" && !(whole && typed) {
+					result.WriteString("    [key: string]: any;\n")
+				}
+				continue
+			}
 			optional := ""
 			if prop.Optional {
 				optional = "?"
@@ -36,74 +80,403 @@ func (t *Transpiler) generateSvelteCode(component *ReactComponent, processedJSX
 		result.WriteString("  };\n")
 
 		// Destructuring de props con valores por defecto
-		result.WriteString("  let { ")
-		propNames := make([]string, len(component.Props))
-		for i, prop := range component.Props {
-			if prop.DefaultValue != "" {
-				propNames[i] = fmt.Sprintf("%s = %s", prop.Name, prop.DefaultValue)
-			} else {
-				propNames[i] = prop.Name
+		var propNames []string
+		for _, prop := range component.Props {
+			switch {
+			case prop.Rest, prop.InRest:
+			case prop.DefaultValue != "":
+				propNames = append(propNames, fmt.Sprintf("%s = %s", prop.Name, prop.DefaultValue))
+			default:
+				propNames = append(propNames, prop.Name)
 			}
 		}
-		result.WriteString(strings.Join(propNames, ", "))
-		result.WriteString(" }: Props = $props();\n\n")
+		// El resto de props va siempre al final
+		if rest != "" {
+			propNames = append(propNames, "..."+rest)
+		}
+		if whole && rest != "" {
+			// El objeto de props se usa entero: let props: Props = $props()
+			result.WriteString(fmt.Sprintf("  let %s: Props = $props();\n\n", rest))
+		} else {
+			result.WriteString("  let { " + strings.Join(propNames, ", ") + " }: Props = $props();\n\n")
+		}
 	}
 
-	// States
-	if len(component.States) > 0 {
-		result.WriteString("  // States\n")
-		for _, state := range component.States {
-			result.WriteString(fmt.Sprintf("  let %s = $state(%s);\n", state.Name, state.InitialValue))
+	t.writeScript(&result, &script, "  ")
+
+	// Marcadores para lo que no se pudo convertir
+	if t.todoMarkers && len(component.Todos) > 0 {
+		for _, todo := range component.Todos {
+			result.WriteString(fmt.Sprintf("  // TODO(r2s): línea %d: %s\n", todo.Line, todo.Message))
+			if todo.Source != "" {
+				for _, line := range strings.Split(dedent(todo.Source), "\n") {
+					result.WriteString(strings.TrimRight("  // "+line, " ") + "\n")
+				}
+			}
 		}
 		result.WriteString("\n")
 	}
 
-	// Functions
-	if len(component.Functions) > 0 {
-		result.WriteString("  // Functions\n")
-		for _, fn := range component.Functions {
-			asyncPrefix := ""
-			if fn.Async {
-				asyncPrefix = "async "
-			}
-			result.WriteString(fmt.Sprintf("  %sfunction %s%s {\n", asyncPrefix, fn.Name, fn.Params))
+	result.WriteString("</script>\n\n")
 
-			// Indentar el cuerpo de la función
-			bodyLines := strings.Split(strings.TrimSpace(fn.Body), "\n")
-			for _, line := range bodyLines {
-				trimmedLine := strings.TrimSpace(line)
-				if trimmedLine != "" {
-					result.WriteString(fmt.Sprintf("    %s\n", trimmedLine))
-				}
+	// HTML (JSX procesado)
+	if processedJSX != "" {
+		result.WriteString(processedJSX)
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// Declaración del script y sección a la que pertenece
+type scriptItem struct {
+	order   int
+	section string
+	text    string
+	block   bool // termina con una línea en blanco propia
+}
+
+// Declaraciones del script en el orden del código original, con un
+// comentario al comienzo de cada sección
+func (t *Transpiler) writeScript(result *strings.Builder, s *Script, indent string) {
+	items := t.scriptItems(s, indent)
+	slices.SortStableFunc(items, func(a, b scriptItem) int { return cmp.Compare(a.order, b.order) })
+
+	for i, item := range items {
+		if i == 0 || item.section != items[i-1].section {
+			if i > 0 && !items[i-1].block {
+				result.WriteString("\n")
 			}
-			result.WriteString("  }\n\n")
+			result.WriteString(indent + "// " + item.section + "\n")
 		}
+		result.WriteString(item.text)
+	}
+	if len(items) > 0 && !items[len(items)-1].block {
+		result.WriteString("\n")
+	}
+}
+
+// Texto de cada declaración del script
+func (t *Transpiler) scriptItems(s *Script, indent string) []scriptItem {
+	var items []scriptItem
+	add := func(order int, section string, block bool, write func(b *strings.Builder)) {
+		var b strings.Builder
+		write(&b)
+		items = append(items, scriptItem{order: order, section: section, text: b.String(), block: block})
+	}
+
+	for _, variable := range s.Variables {
+		add(variable.Order, "Variables", false, func(b *strings.Builder) {
+			writeIndented(b, variable.Code, indent)
+		})
+	}
+
+	for _, state := range s.States {
+		add(state.Order, "States", false, func(b *strings.Builder) {
+			rune := "$state"
+			if state.Type != "any" {
+				rune += "<" + state.Type + ">"
+			}
+			b.WriteString(indent + fmt.Sprintf("let %s = %s(%s);\n", state.Name, rune, state.InitialValue))
+		})
+	}
+
+	for _, reducer := range s.Reducers {
+		add(reducer.Order, "Reducers", true, func(b *strings.Builder) {
+			declaration := reducer.Name
+			if reducer.Type != "any" {
+				declaration += ": " + reducer.Type
+			}
+			b.WriteString(indent + fmt.Sprintf("let %s = $state(%s);\n", declaration, reducer.InitialValue))
+			if reducer.Dispatch != "" {
+				b.WriteString(indent + fmt.Sprintf("function %s(action: %s) {\n", reducer.Dispatch, reducer.ActionType))
+				b.WriteString(indent + fmt.Sprintf("  %s = %s(%s, action);\n", reducer.Name, reducer.Reducer, reducer.Name))
+				b.WriteString(indent + "}\n")
+			}
+			b.WriteString("\n")
+		})
+	}
+
+	for _, ref := range s.Refs {
+		add(ref.Order, "Refs", false, func(b *strings.Builder) {
+			if ref.Component {
+				b.WriteString(indent + fmt.Sprintf("let %s = $state<%s>();\n", ref.Name, ref.Type))
+				return
+			}
+			declaration := ref.Name
+			if ref.Type != "" {
+				declaration += ": " + ref.Type
+			}
+			if ref.InitialValue != "" {
+				declaration += " = " + ref.InitialValue
+			}
+			b.WriteString(indent + fmt.Sprintf("let %s;\n", declaration))
+		})
+	}
+
+	for _, usage := range s.HookUsages {
+		add(usage.Order, "Hooks", false, func(b *strings.Builder) {
+			if usage.Target == "" {
+				b.WriteString(indent + fmt.Sprintf("%s;\n", usage.Expression))
+			} else {
+				b.WriteString(indent + fmt.Sprintf("%s %s = %s;\n", usage.Kind, usage.Target, usage.Expression))
+			}
+		})
+	}
+
+	for _, derived := range s.Derived {
+		add(derived.Order, "Derived", false, func(b *strings.Builder) {
+			declaration := derived.Name
+			if derived.Type != "" {
+				declaration += ": " + derived.Type
+			}
+			if derived.Body == "" {
+				b.WriteString(indent + fmt.Sprintf("let %s = $derived(%s);\n", declaration, derived.Expression))
+				return
+			}
+			b.WriteString(indent + fmt.Sprintf("let %s = $derived.by(() => {\n", declaration))
+			writeIndented(b, derived.Body, indent+"  ")
+			b.WriteString(indent + "});\n")
+		})
+	}
+
+	for _, usage := range s.ContextUsages {
+		add(usage.Order, "Context", false, func(b *strings.Builder) {
+			if usage.Target == "" {
+				b.WriteString(indent + fmt.Sprintf("%s;\n", usage.Expression))
+			} else {
+				b.WriteString(indent + fmt.Sprintf("%s %s = %s;\n", usage.Kind, usage.Target, usage.Expression))
+			}
+		})
+	}
+
+	for _, fn := range s.Functions {
+		add(fn.Order, "Functions", true, func(b *strings.Builder) {
+			writeFunction(b, fn, "", indent)
+		})
 	}
 
 	// Effects (convertir a $effect)
-	if len(component.Effects) > 0 {
-		result.WriteString("  // Effects\n")
-		for _, effect := range component.Effects {
-			result.WriteString("  $effect(() => {\n")
-			// Indentar el cuerpo del efecto
-			bodyLines := strings.Split(strings.TrimSpace(effect.Body), "\n")
-			for _, line := range bodyLines {
-				trimmedLine := strings.TrimSpace(line)
-				if trimmedLine != "" {
-					result.WriteString(fmt.Sprintf("    %s\n", trimmedLine))
+	for _, effect := range s.Effects {
+		add(effect.Order, "Effects", true, func(b *strings.Builder) {
+			if t.mounts(effect) {
+				b.WriteString(indent + "onMount(() => {\n")
+				writeIndented(b, effect.Body, indent+"  ")
+				b.WriteString(indent + "});\n\n")
+				return
+			}
+
+			b.WriteString(indent + fmt.Sprintf("%s(() => {\n", effect.Kind))
+			if effect.Untrack {
+				// Leer solo las dependencias y ejecutar el cuerpo sin registrar lecturas
+				for _, dep := range effect.Dependencies {
+					b.WriteString(indent + fmt.Sprintf("  %s;\n", dep))
+				}
+				if effect.Cleanup {
+					b.WriteString(indent + "  return untrack(() => {\n")
+				} else {
+					b.WriteString(indent + "  untrack(() => {\n")
 				}
+				writeIndented(b, effect.Body, indent+"    ")
+				b.WriteString(indent + "  });\n")
+			} else {
+				// Indentar el cuerpo del efecto
+				writeIndented(b, effect.Body, indent+"  ")
 			}
-			result.WriteString("  });\n\n")
+			b.WriteString(indent + "});\n\n")
+		})
+	}
+	return items
+}
+
+// Generar un módulo TypeScript para un archivo sin componente: contextos,
+// funciones auxiliares y hooks convertidos
+func (t *Transpiler) generateModule(component *ReactComponent) string {
+	var result strings.Builder
+
+	svelteImports := t.svelteImports(component)
+	if len(component.Contexts) > 0 {
+		svelteImports = append(svelteImports, "getContext", "setContext")
+		slices.Sort(svelteImports)
+		svelteImports = slices.Compact(svelteImports)
+	}
+	writeImports(&result, svelteImports, component.Imports, "")
+	for _, typ := range component.Types {
+		writeIndented(&result, typ, "")
+		result.WriteString("\n")
+	}
+	for _, constant := range component.Constants {
+		writeIndented(&result, constant, "")
+		result.WriteString("\n")
+	}
+	writeContexts(&result, component.Contexts, "")
+
+	for _, fn := range slices.Concat(component.ModuleFunctions, component.Functions) {
+		prefix := ""
+		if fn.Exported {
+			prefix = "export "
 		}
+		writeFunction(&result, fn, prefix, "")
 	}
 
-	result.WriteString("</script>\n\n")
+	for _, hook := range component.Hooks {
+		prefix := ""
+		if hook.Exported {
+			prefix = "export "
+		}
+		t.writeHook(&result, hook, prefix, "")
+	}
 
-	// HTML (JSX procesado)
-	if processedJSX != "" {
-		result.WriteString(processedJSX)
+	for _, ctx := range component.Contexts {
+		if ctx.ExportDefault {
+			result.WriteString(fmt.Sprintf("export default %s;\n", ctx.Name))
+		}
+	}
+	for _, hook := range component.Hooks {
+		if hook.ExportDefault {
+			result.WriteString(fmt.Sprintf("export default %s;\n", hook.Name))
+		}
+	}
+	return strings.TrimRight(result.String(), "\n") + "\n"
+}
+
+// <script module> de un componente: imports, tipos, constantes, contextos,
+// funciones auxiliares y hooks del módulo, exportados para los demás
+// componentes del archivo
+func (t *Transpiler) writeModuleScript(result *strings.Builder, component *ReactComponent, indent string) {
+	svelteImports := t.svelteImports(component)
+	if len(component.Contexts) > 0 {
+		svelteImports = append(svelteImports, "getContext", "setContext")
+		slices.Sort(svelteImports)
+		svelteImports = slices.Compact(svelteImports)
+	}
+	writeImports(result, svelteImports, component.Imports, indent)
+	for _, decl := range slices.Concat(component.Types, component.Constants) {
+		if !strings.HasPrefix(decl, "export ") {
+			decl = "export " + decl
+		}
+		writeIndented(result, decl, indent)
 		result.WriteString("\n")
 	}
+	writeContexts(result, component.Contexts, indent)
+	for _, fn := range component.ModuleFunctions {
+		writeFunction(result, fn, "export ", indent)
+	}
+	for _, hook := range component.Hooks {
+		t.writeHook(result, hook, "export ", indent)
+	}
+}
 
-	return result.String()
+// Cada contexto es una clave exportada con sus funciones setX/getX
+func writeContexts(result *strings.Builder, contexts []ContextDefinition, indent string) {
+	for _, ctx := range contexts {
+		getter := fmt.Sprintf("getContext<%s>(%s)", ctx.Type, ctx.Name)
+		if ctx.DefaultValue != "" {
+			getter = fmt.Sprintf("getContext<%s | undefined>(%s) ?? %s", ctx.Type, ctx.Name, ctx.DefaultValue)
+		}
+		lines := []string{
+			fmt.Sprintf("export const %s = Symbol('%s');", ctx.Name, ctx.Name),
+			"",
+			fmt.Sprintf("export function %s(value: %s) {", contextSetter(ctx.Name), ctx.Type),
+			fmt.Sprintf("  return setContext(%s, value);", ctx.Name),
+			"}",
+			"",
+			fmt.Sprintf("export function %s(): %s {", contextGetter(ctx.Name), ctx.Type),
+			fmt.Sprintf("  return %s;", getter),
+			"}",
+			"",
+		}
+		for _, line := range lines {
+			if line != "" {
+				result.WriteString(indent + line)
+			}
+			result.WriteString("\n")
+		}
+	}
+}
+
+// Imports de svelte y del componente, seguidos de una línea en blanco
+func writeImports(result *strings.Builder, svelteImports, imports []string, indent string) {
+	if len(svelteImports) > 0 {
+		result.WriteString(fmt.Sprintf("%simport { %s } from 'svelte'\n", indent, strings.Join(svelteImports, ", ")))
+	}
+	for _, imp := range imports {
+		imp = strings.ReplaceAll(imp, ".jsx", ".svelte")
+		imp = strings.ReplaceAll(imp, ".tsx", ".svelte")
+		result.WriteString(fmt.Sprintf("%s%s\n", indent, imp))
+	}
+	if len(svelteImports) > 0 || len(imports) > 0 {
+		result.WriteString("\n")
+	}
+}
+
+// Función de un hook convertido, con su script y el objeto que devuelve
+func (t *Transpiler) writeHook(result *strings.Builder, hook HookDefinition, prefix, indent string) {
+	returnType := ""
+	if hook.ReturnType != "" {
+		returnType = ": " + hook.ReturnType
+	}
+	result.WriteString(fmt.Sprintf("%s%sfunction %s%s%s {\n", indent, prefix, hook.Name, hook.Params, returnType))
+	t.writeScript(result, &hook.Script, indent+"  ")
+	if hook.Return != "" {
+		writeIndented(result, hook.Return, indent+"  ")
+	}
+	result.WriteString(indent + "}\n\n")
+}
+
+func writeFunction(result *strings.Builder, fn FunctionDefinition, prefix, indent string) {
+	if fn.Async {
+		prefix += "async "
+	}
+	returnType := ""
+	if fn.ReturnType != "" {
+		returnType = ": " + fn.ReturnType
+	}
+	result.WriteString(fmt.Sprintf("%s%sfunction %s%s%s {\n", indent, prefix, fn.Name, fn.Params, returnType))
+
+	// Indentar el cuerpo de la función
+	writeIndented(result, fn.Body, indent+"  ")
+	result.WriteString(indent + "}\n\n")
+}
+
+// Indica si el efecto se emite como onMount
+func (t *Transpiler) mounts(effect EffectDefinition) bool {
+	return t.onMount && effect.Kind == EffectKindEffect && effect.Dependencies != nil && len(effect.Dependencies) == 0
+}
+
+// Funciones de svelte que usa el código generado
+func (t *Transpiler) svelteImports(component *ReactComponent) []string {
+	var mount, untrack bool
+	effects := component.Effects
+	for _, hook := range component.Hooks {
+		effects = append(effects, hook.Effects...)
+	}
+	for _, effect := range effects {
+		if t.mounts(effect) {
+			mount = true
+		} else if effect.Untrack {
+			untrack = true
+		}
+	}
+
+	names := slices.Clone(component.SvelteImports)
+	if mount {
+		names = append(names, "onMount")
+	}
+	if untrack {
+		names = append(names, "untrack")
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Escribir un bloque de código con la indentación dada, conservando la
+// indentación relativa de sus líneas y omitiendo las líneas vacías
+func writeIndented(result *strings.Builder, body, prefix string) {
+	body = dedent("\n" + strings.Trim(body, "\n"))
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) != "" {
+			result.WriteString(prefix + strings.TrimRight(line, " \t") + "\n")
+		}
+	}
 }
@@ -0,0 +1,102 @@
+package transpiler
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerar las salidas esperadas de testdata/")
+
+// Cada componente de testdata/ se compara con la salida del mismo nombre
+func TestGolden(t *testing.T) {
+	tr := NewTranspiler()
+	for _, src := range loadCorpus(t) {
+		t.Run(src.Filename, func(t *testing.T) {
+			result, err := tr.TranspileComponent(src.Filename, src.Code)
+			if err != nil {
+				t.Fatalf("TranspileComponent: %v", err)
+			}
+
+			// Los demás componentes del archivo se comparan con Base.Nombre.svelte
+			base := strings.TrimSuffix(src.Filename, filepath.Ext(src.Filename))
+			compareGolden(t, filepath.Join("testdata", base+result.Extension), result.Code)
+			for _, file := range result.Files {
+				compareGolden(t, filepath.Join("testdata", base+"."+file.Name), file.Code)
+			}
+			compareDiagnostics(t, filepath.Join("testdata", base+".diagnostics"), result.Diagnostics)
+		})
+	}
+}
+
+// Los diagnósticos se comparan con Base.diagnostics, un diagnóstico por
+// línea; el archivo no existe si el componente no produce ninguno
+func compareDiagnostics(t *testing.T, golden string, diags []Diagnostic) {
+	t.Helper()
+	var got strings.Builder
+	for _, d := range diags {
+		got.WriteString(d.Error() + "\n")
+	}
+	if *update {
+		if got.Len() == 0 {
+			if err := os.Remove(golden); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Fatal(err)
+			}
+			return
+		}
+		if err := os.WriteFile(golden, []byte(got.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+	if got.String() != string(want) {
+		t.Errorf("los diagnósticos no coinciden con %s\n%s", golden, lineDiff(string(want), got.String()))
+	}
+}
+
+func compareGolden(t *testing.T, golden, got string) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (ejecuta go test -update para generarlo)", err)
+	}
+	if got != string(want) {
+		t.Errorf("la salida no coincide con %s\n%s", golden, lineDiff(string(want), got))
+	}
+}
+
+// Primera línea distinta entre la salida esperada y la obtenida
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("línea %d:\n  esperado: %s\n  obtenido: %s", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,459 @@
+package transpiler
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Los hooks personalizados (funciones use*) de un archivo sin componente se
+// convierten en funciones de un módulo .svelte.ts que usan runes. Como un
+// valor $state copiado pierde la reactividad, el objeto devuelto expone los
+// valores reactivos con getters y los componentes acceden a ellos a través
+// del objeto (counter.count) en lugar de desestructurarlo.
+
+// Variables globales del navegador que no deben ocultarse con el objeto de
+// un hook (useLocalStorage -> localStorage)
+var browserGlobals = map[string]bool{
+	"window": true, "document": true, "localStorage": true, "sessionStorage": true,
+	"location": true, "history": true, "navigator": true, "screen": true,
+	"performance": true, "console": true, "fetch": true, "event": true,
+	"name": true, "status": true, "origin": true,
+}
+
+// useAlgo: use seguido de mayúscula
+func isHookName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "use")
+	for _, r := range rest {
+		return ok && unicode.IsUpper(r)
+	}
+	return false
+}
+
+// Módulo del propio proyecto: ruta relativa o alias habitual de la raíz
+func isLocalModule(source string) bool {
+	for _, prefix := range []string{".", "@/", "~/", "$lib/"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ruta del módulo .svelte.ts que sustituye a un módulo de hooks
+func hookModulePath(source string) string {
+	switch path.Ext(source) {
+	case ".ts", ".tsx", ".js", ".jsx":
+		source = strings.TrimSuffix(source, path.Ext(source))
+	}
+	return source + ".svelte.js"
+}
+
+// Localizar los hooks personalizados: los declarados en el archivo y los
+// importados de módulos del proyecto
+func (c *converter) findHooks() {
+	for _, stmt := range c.file.body {
+		switch d := unwrapExport(stmt).(type) {
+		case *importDecl:
+			if !isLocalModule(d.source) {
+				continue
+			}
+			for _, spec := range d.named {
+				if isHookName(spec.local) && !spec.typeOnly {
+					c.hooks[spec.local] = true
+				}
+			}
+			if isHookName(d.defaultName) {
+				c.hooks[d.defaultName] = true
+			}
+		case *funcDecl:
+			if isHookName(d.name) {
+				c.hooks[d.name] = true
+			}
+		case *varDecl:
+			for _, v := range d.decls {
+				id, ok := v.target.(*ident)
+				if _, isFn := unparen(v.init).(*function); ok && isFn && isHookName(id.name) {
+					c.hooks[id.name] = true
+				}
+			}
+		}
+	}
+}
+
+// Indica si el import trae hooks personalizados
+func (c *converter) importsHooks(imp *importDecl) bool {
+	if !isLocalModule(imp.source) {
+		return false
+	}
+	if c.hooks[imp.defaultName] {
+		return true
+	}
+	for _, spec := range imp.named {
+		if c.hooks[spec.local] {
+			return true
+		}
+	}
+	return false
+}
+
+// Funciones use* declaradas en el nivel superior del archivo
+func (c *converter) hookFunctions() []*componentDecl {
+	var hooks []*componentDecl
+	for _, stmt := range c.file.body {
+		switch d := unwrapExport(stmt).(type) {
+		case *funcDecl:
+			if isHookName(d.name) && d.fn.body != nil {
+				hooks = append(hooks, &componentDecl{name: d.name, fn: d.fn, stmt: stmt})
+			}
+		case *varDecl:
+			if len(d.decls) != 1 {
+				continue
+			}
+			id, ok := d.decls[0].target.(*ident)
+			fn, isFn := unparen(d.decls[0].init).(*function)
+			if ok && isFn && isHookName(id.name) {
+				hooks = append(hooks, &componentDecl{name: id.name, fn: fn, stmt: stmt})
+			}
+		}
+	}
+	return hooks
+}
+
+// Convertir cada hook del archivo en una función con runes
+func (c *converter) extractHooks() []HookDefinition {
+	var hooks []HookDefinition
+	for _, decl := range c.hookFunctions() {
+		c.consumed[decl.stmt] = true
+		if vd, ok := unwrapExport(decl.stmt).(*varDecl); ok {
+			c.consumed[vd.decls[0]] = true
+		}
+		exp, exported := decl.stmt.(*exportDecl)
+		hook := HookDefinition{
+			Name:          decl.name,
+			Params:        c.functionParams(decl.fn),
+			ReturnType:    decl.fn.returnType,
+			Exported:      exported && !exp.isDefault,
+			ExportDefault: exported && exp.isDefault,
+		}
+
+		var body []node
+		if decl.fn.body != nil {
+			body = decl.fn.body.body
+		}
+
+		hook.States = c.extractStates(body)
+		c.registerStates(hook.States)
+		hook.Reducers = c.extractReducers(body)
+		hook.Refs = c.extractRefs(nil, body)
+		for _, ref := range hook.Refs {
+			c.refs[ref.Name] = true
+		}
+
+		reactive := make(map[string]bool)
+		for _, state := range hook.States {
+			reactive[state.Name] = true
+		}
+		for _, reducer := range hook.Reducers {
+			reactive[reducer.Name] = true
+		}
+		for _, name := range reducerTargets(body) {
+			reactive[name] = true
+		}
+		for _, ref := range hook.Refs {
+			reactive[ref.Name] = true
+		}
+		hook.HookUsages = c.extractHookUsages(body, reactive)
+		_, hook.ContextUsages = c.extractContexts(nil, body)
+		hook.Derived = c.extractDerived(body, reactive)
+		hook.Effects = c.extractEffects(body, reactive)
+		hook.Functions = c.collectFunctions(nil, body, c.plainLocals(body))
+		hook.Variables = c.extractVariables(body)
+
+		// El último return del cuerpo es el valor del hook
+		if len(body) > 0 {
+			if ret, ok := body[len(body)-1].(*returnStmt); ok {
+				c.consumed[ret] = true
+				hook.Return = c.hookReturn(&hook, ret, reactive)
+			}
+		}
+		for _, stmt := range body {
+			if _, ok := stmt.(*emptyStmt); !ok {
+				c.reportStatement(stmt, "sentencia del hook no convertida")
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+
+	// export default useAlgo;
+	for _, stmt := range c.file.body {
+		exp, ok := stmt.(*exportDecl)
+		if !ok || !exp.isDefault {
+			continue
+		}
+		id, ok := unparen(exp.expr).(*ident)
+		if !ok {
+			continue
+		}
+		for i := range hooks {
+			if hooks[i].Name == id.name {
+				hooks[i].ExportDefault = true
+				c.consumed[stmt] = true
+			}
+		}
+	}
+	return hooks
+}
+
+// Sentencia return del hook, con getters para los valores reactivos
+func (c *converter) hookReturn(hook *HookDefinition, ret *returnStmt, reactive map[string]bool) string {
+	if ret.arg == nil {
+		return "return;"
+	}
+	arg := unparen(ret.arg)
+	if as, ok := arg.(*asExpr); ok && as.op == "as" {
+		arg = unparen(as.expr)
+	}
+
+	var entries []string
+	switch a := arg.(type) {
+	case *objectLit:
+		entries = c.getterEntries(a, reactive)
+
+	// [valor, setValor]: un objeto con índices, al que se accede como hook[0]
+	case *arrayLit:
+		for i, elem := range a.elems {
+			if _, spread := elem.(*spreadElem); spread || elem == nil {
+				c.warn(ret, CodeHookReturn, "el hook %s devuelve un array que no se puede convertir en getters", hook.Name)
+				return "return " + c.print(ret.arg) + ";"
+			}
+			entries = append(entries, c.getterEntry(fmt.Sprint(i), elem, reactive))
+		}
+		// El tipo de retorno declarado como tupla pasa a ser el del objeto
+		if hook.ReturnType != "" {
+			hook.ReturnType = tupleObjectType(hook.ReturnType)
+		}
+
+	default:
+		if names := references(arg, reactive); len(names) > 0 {
+			c.warn(ret, CodeHookReturn, "el hook %s devuelve un valor que lee %s y no se actualizará en quien lo llama; devuelva un objeto", hook.Name, strings.Join(names, ", "))
+		}
+		return "return " + c.print(ret.arg) + ";"
+	}
+
+	if len(entries) == 0 {
+		return "return {};"
+	}
+	return "return {\n  " + strings.Join(entries, ",\n  ") + ",\n};"
+}
+
+// Etiqueta de un elemento de una tupla: [on: boolean, toggle: () => void]
+var tupleLabel = regexp.MustCompile(`^[A-Za-z_$][\w$]*(\?)?\s*:`)
+
+// Tipo del objeto con índices que sustituye a un array devuelto por un hook:
+// [boolean, () => void] -> { 0: boolean; 1: () => void }. Si el tipo no es una
+// tupla literal (un alias, elementos opcionales o rest) se descarta, porque
+// el objeto con getters no lo cumpliría.
+func tupleObjectType(typ string) string {
+	typ = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(typ), "readonly "))
+	if !strings.HasPrefix(typ, "[") || closingBracket(typ) != len(typ)-1 {
+		return ""
+	}
+	var members []string
+	for i, elem := range splitTopLevel(typ[1:len(typ)-1], ',') {
+		if label := tupleLabel.FindStringSubmatch(elem); label != nil {
+			if label[1] != "" {
+				return ""
+			}
+			elem = elem[len(label[0]):]
+		}
+		if strings.HasPrefix(elem, "...") || strings.HasSuffix(elem, "?") {
+			return ""
+		}
+		members = append(members, fmt.Sprintf("%d: %s", i, strings.TrimSpace(elem)))
+	}
+	if len(members) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(members, "; ") + " }"
+}
+
+// Posición del corchete que cierra el que abre el texto, o -1
+func closingBracket(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Propiedad de un objeto: getter si lee valores reactivos
+func (c *converter) getterEntry(key string, value node, reactive map[string]bool) string {
+	if _, isFn := unparen(value).(*function); !isFn && len(references(value, reactive)) > 0 {
+		return fmt.Sprintf("get %s() { return %s; }", key, c.print(value))
+	}
+	return key + ": " + c.print(value)
+}
+
+// Propiedades de un objeto literal, con getters en las que leen valores
+// reactivos para que quien recibe el objeto vea sus cambios
+func (c *converter) getterEntries(obj *objectLit, reactive map[string]bool) []string {
+	var entries []string
+	for _, prop := range obj.props {
+		key, isIdent := prop.key.(*ident)
+		switch {
+		case prop.spread || prop.computed || !isIdent:
+			entries = append(entries, c.print(prop))
+		case prop.shorthand && reactive[key.name]:
+			entries = append(entries, c.getterEntry(key.name, prop.value, reactive))
+		case prop.shorthand:
+			entries = append(entries, c.print(prop))
+		default:
+			entries = append(entries, c.getterEntry(key.name, prop.value, reactive))
+		}
+	}
+	return entries
+}
+
+// { get mode() { return mode; }, toggle }
+func (c *converter) getterObject(obj *objectLit, reactive map[string]bool) string {
+	entries := c.getterEntries(obj, reactive)
+	if len(entries) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
+
+// Extraer las llamadas a hooks personalizados del componente o de un hook.
+// Los nombres desestructurados pasan a leerse del objeto devuelto y se
+// añaden a reactive
+func (c *converter) extractHookUsages(body []node, reactive map[string]bool) []HookUsage {
+	var usages []HookUsage
+	for _, stmt := range body {
+		// useDocumentTitle(title);
+		if es, ok := stmt.(*exprStmt); ok {
+			if hook, ok := unparen(es.expr).(*hookCall); ok && c.hooks[hook.hook] {
+				c.consumed[stmt] = true
+				usages = append(usages, HookUsage{Expression: c.print(hook), Order: c.order(stmt)})
+			}
+			continue
+		}
+
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			hook, ok := unparen(d.init).(*hookCall)
+			if !ok || !c.hooks[hook.hook] {
+				continue
+			}
+			c.consumed[d] = true
+			usage := HookUsage{Kind: "const", Expression: c.print(d.init), Order: c.order(d)}
+
+			switch target := d.target.(type) {
+			case *ident:
+				usage.Kind = vd.kind
+				usage.Target = target.name
+				reactive[target.name] = true
+			case *objectPattern:
+				usage.Target = c.hookObjectName(hook.hook)
+				if !c.renameBindings(d, usage.Target, reactive) {
+					usage.Kind, usage.Target = vd.kind, c.print(d.target)
+				}
+			case *arrayPattern:
+				usage.Target = c.hookObjectName(hook.hook)
+				if !c.renameBindings(d, usage.Target, reactive) {
+					usage.Kind, usage.Target = vd.kind, c.print(d.target)
+				}
+			}
+			usages = append(usages, usage)
+		}
+	}
+	return usages
+}
+
+// Sustituir las variables de { a, b: c } o [a, b] por object.a, object[0]...;
+// devuelve false, con un aviso, si el patrón no es simple
+func (c *converter) renameBindings(d *declarator, object string, reactive map[string]bool) bool {
+	renames, ok := bindingAccesses(d.target, object)
+	if !ok {
+		c.warn(d, CodeHookReturn, "la desestructuración del resultado del hook pierde la reactividad; use el objeto devuelto")
+		return false
+	}
+	for local, access := range renames {
+		c.renames[local] = access
+		reactive[local] = true
+	}
+	return true
+}
+
+// Acceso a object que sustituye a cada variable del patrón; false si el
+// patrón tiene valores por defecto, resto o destinos anidados
+func bindingAccesses(target node, object string) (map[string]string, bool) {
+	renames := make(map[string]string)
+	switch target := target.(type) {
+	case *objectPattern:
+		for _, prop := range target.props {
+			key, ok := prop.key.(*ident)
+			local, isIdent := prop.value.(*ident)
+			if !ok || !isIdent || prop.rest || prop.computed || prop.def != nil {
+				return nil, false
+			}
+			renames[local.name] = object + "." + key.name
+		}
+	case *arrayPattern:
+		for i, elem := range target.elems {
+			if elem == nil {
+				continue
+			}
+			local, ok := elem.target.(*ident)
+			if !ok || elem.rest || elem.def != nil {
+				return nil, false
+			}
+			renames[local.name] = fmt.Sprintf("%s[%d]", object, i)
+		}
+	}
+	return renames, true
+}
+
+// Nombre de la variable que guarda el resultado de un hook: useCart -> cart
+func (c *converter) hookObjectName(hook string) string {
+	return c.objectName(strings.TrimPrefix(hook, "use"))
+}
+
+// Variable nueva con el nombre dado en minúscula, que no oculta ningún
+// identificador del archivo ni una variable del navegador
+func (c *converter) objectName(base string) string {
+	if base == "" {
+		base = "value"
+	}
+	base = strings.ToLower(base[:1]) + base[1:]
+	if browserGlobals[base] {
+		base += "State"
+	}
+
+	used := make(map[string]bool)
+	walk(c.file, func(n node) bool {
+		if id, ok := n.(*ident); ok {
+			used[id.name] = true
+		}
+		return true
+	})
+	name := base
+	for i := 2; used[name] || c.hookObjects[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	c.hookObjects[name] = true
+	return name
+}
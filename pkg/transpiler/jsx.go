@@ -0,0 +1,505 @@
+package transpiler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Elementos HTML sin contenido, que pueden escribirse como <br />
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "source": true,
+	"track": true, "wbr": true,
+}
+
+// Elementos SVG que admiten la forma auto-cerrada
+var svgElements = map[string]bool{
+	"circle": true, "ellipse": true, "line": true, "path": true, "polygon": true,
+	"polyline": true, "rect": true, "stop": true, "use": true, "image": true,
+	"animate": true, "animateTransform": true, "feBlend": true, "feGaussianBlur": true,
+	"feOffset": true, "feColorMatrix": true,
+}
+
+// Procesar JSX y convertirlo a sintaxis de Svelte
+func (c *converter) processJSX() string {
+	if len(c.earlyReturns) > 0 {
+		return c.processBranches()
+	}
+	return c.printMarkup(c.markup)
+}
+
+// Markup de un valor devuelto por el componente
+func (c *converter) printMarkup(n node) string {
+	if n == nil {
+		return ""
+	}
+
+	var processed string
+	switch root := n.(type) {
+	case *jsxElement, *jsxFragment:
+		processed = c.print(root)
+	case *literal:
+		// return null: el componente no renderiza nada
+		return ""
+	default:
+		start, _ := root.bounds()
+		processed = c.printContainer(root, start, nodeText(c.src, root))
+	}
+
+	return dedent(strings.TrimSpace(processed))
+}
+
+// Retornos anticipados y JSX principal como una cadena {#if}...{:else}
+func (c *converter) processBranches() string {
+	return c.printBranches(c.earlyReturns, c.printMarkup(c.markup))
+}
+
+// Cadena {#if}...{:else if}...{:else}{/if}; final es el markup de {:else}
+func (c *converter) printBranches(branches []earlyReturn, final string) string {
+	var b strings.Builder
+	branch := func(header, markup string) {
+		b.WriteString(header + "\n")
+		if markup == "" {
+			return
+		}
+		for _, line := range strings.Split(markup, "\n") {
+			if line != "" {
+				b.WriteString("  " + line)
+			}
+			b.WriteString("\n")
+		}
+	}
+	for i, early := range branches {
+		header := "{:else if " + c.print(early.test) + "}"
+		if i == 0 {
+			header = "{#if " + c.print(early.test) + "}"
+		}
+		markup := c.printMarkup(early.markup)
+		if len(early.branches) > 0 {
+			markup = c.printBranches(early.branches, markup)
+		}
+		branch(header, markup)
+	}
+	if final != "" {
+		branch("{:else}", final)
+	}
+	b.WriteString("{/if}")
+	return b.String()
+}
+
+// Reescribir los nodos JSX como markup de Svelte
+func (c *converter) replaceMarkup(n, parent node) (string, bool) {
+	switch n := n.(type) {
+	case *jsxFragment:
+		return c.deleteFragments(n, n.children), true
+	case *jsxElement:
+		if c.isTransparent(n) {
+			return c.deleteFragments(n, n.children), true
+		}
+		if s := c.siblings[n.name]; s != nil && s.snippet {
+			return c.renderSnippet(n), true
+		}
+		return c.printElement(n), true
+	case *jsxAttr:
+		if text, ok := c.bindings[n]; ok {
+			return text, true
+		}
+		if el, ok := parent.(*jsxElement); ok && n.name == "style" && isHTMLName(el.name) {
+			if text, ok := c.printStyle(n); ok {
+				return text, true
+			}
+		}
+		el, _ := parent.(*jsxElement)
+		return c.printAttr(el, n), true
+	case *jsxSpreadAttr:
+		if el, ok := parent.(*jsxElement); ok && isHTMLName(el.name) {
+			if obj, ok := unparen(n.arg).(*objectLit); ok {
+				return "{..." + c.printSpreadObject(el, obj) + "}", true
+			}
+		}
+	case *jsxExprContainer:
+		if _, inAttr := parent.(*jsxAttr); inAttr {
+			return "", false
+		}
+		if n.expr == nil {
+			return c.replaceComments(n), true
+		}
+		if text, ok := c.renderTag(n.expr); ok {
+			return text, true
+		}
+		return c.printContainer(n.expr, n.start, c.code.printChildren(n)), true
+	}
+	return "", false
+}
+
+func isFragmentName(name string) bool {
+	return name == "React.Fragment" || name == "Fragment"
+}
+
+// Elementos que no se emiten, solo sus hijos: fragmentos y providers de
+// contexto, que pasan a ser un setContext en el script
+func (c *converter) isTransparent(el *jsxElement) bool {
+	if isFragmentName(el.name) {
+		return true
+	}
+	_, ok := c.providerContext(el)
+	return ok
+}
+
+// Los fragmentos no son necesarios en Svelte: se emiten solo sus hijos
+func (c *converter) deleteFragments(parent node, children []node) string {
+	var b strings.Builder
+	for _, child := range children {
+		b.WriteString(c.code.printNode(child, parent))
+	}
+	return b.String()
+}
+
+func (c *converter) printElement(el *jsxElement) string {
+	var b strings.Builder
+	nameEnd := el.start + strings.Index(c.src[el.start:], el.name) + len(el.name)
+	b.WriteString(c.src[el.start:nameEnd])
+
+	snippets, childSnippet := c.elementSnippets(el)
+	c.bindControlled(el)
+	c.checkChangeEvents(el)
+	html := c.dropAttributes(el)
+	cur := nameEnd
+	for _, attr := range el.attrs {
+		as, ae := attr.bounds()
+		if c.skip[attr] {
+			cur = ae
+			continue
+		}
+		b.WriteString(c.src[cur:as])
+		b.WriteString(c.code.printNode(attr, el))
+		cur = ae
+	}
+
+	// Render props: el componente pasa a tener los {#snippet} como hijos
+	if len(snippets) > 0 {
+		if el.selfClosing {
+			b.WriteString(strings.TrimRight(strings.TrimSuffix(c.src[cur:el.end], "/>"), " \t\n") + ">")
+		} else {
+			b.WriteString(c.src[cur:el.openEnd])
+		}
+		b.WriteString(c.snippetArgs(el, snippets))
+		if el.selfClosing || childSnippet || len(el.children) == 0 {
+			b.WriteString("\n" + lineIndent(c.src, el.start) + "</" + el.name + ">")
+			return b.String()
+		}
+		closeStart := el.openEnd
+		for _, child := range el.children {
+			b.WriteString(c.code.printNode(child, el))
+			_, closeStart = child.bounds()
+		}
+		b.WriteString(c.src[closeStart:el.end])
+		return b.String()
+	}
+
+	// dangerouslySetInnerHTML: el HTML sustituye a los hijos
+	if html != nil {
+		if el.selfClosing {
+			b.WriteString(strings.TrimRight(strings.TrimSuffix(c.src[cur:el.end], "/>"), " \t\n") + ">")
+		} else {
+			b.WriteString(c.src[cur:el.openEnd])
+		}
+		b.WriteString("{@html " + c.print(html) + "}</" + el.name + ">")
+		return b.String()
+	}
+
+	if el.selfClosing {
+		tail := c.src[cur:el.end]
+		if isHTMLName(el.name) && !voidElements[el.name] && !svgElements[el.name] {
+			// Svelte no admite <div /> para elementos que no son void
+			tail = strings.TrimRight(strings.TrimSuffix(tail, "/>"), " \t\n") + "></" + el.name + ">"
+		}
+		b.WriteString(tail)
+		return b.String()
+	}
+
+	b.WriteString(c.src[cur:el.openEnd])
+	closeStart := el.openEnd
+	for _, child := range el.children {
+		b.WriteString(c.code.printNode(child, el))
+		_, closeStart = child.bounds()
+	}
+	b.WriteString(c.src[closeStart:el.end])
+	return b.String()
+}
+
+// Las etiquetas en minúscula son elementos HTML; el resto son componentes
+func isHTMLName(name string) bool {
+	return name != "" && name[0] >= 'a' && name[0] <= 'z' && !strings.ContainsAny(name, ".:")
+}
+
+func (c *converter) printAttr(el *jsxElement, attr *jsxAttr) string {
+	name := c.attributeName(el, attr.name)
+	// Un componente expone su elemento como la prop enlazable ref, igual
+	// que los convertidos desde forwardRef
+	if attr.name == "ref" {
+		if _, ok := refTarget(attr); !ok {
+			c.warn(attr, CodeRefCallback, "ref solo se convierte a bind:this o bind:ref cuando recibe una variable")
+		} else if el != nil && !isHTMLName(el.name) {
+			name = "bind:ref"
+		} else {
+			name = "bind:this"
+		}
+	}
+
+	if attr.value == nil {
+		if enumeratedBooleans[name] && el != nil && isHTMLName(el.name) {
+			return name + "=\"true\""
+		}
+		return name
+	}
+	value := attr.value
+	if container, ok := value.(*jsxExprContainer); ok && container.expr != nil {
+		value = container.expr
+	}
+	if isJSX(value) {
+		c.warn(value, CodeJSXAttributeNode, "el atributo %s recibe un elemento JSX, que Svelte no admite como valor", attr.name)
+	}
+	return name + "=" + c.code.printNode(attr.value, attr)
+}
+
+// {...{ className: 'a', onClick }} en un elemento HTML: las claves se
+// renombran como los atributos
+func (c *converter) printSpreadObject(el *jsxElement, obj *objectLit) string {
+	entries := make([]string, len(obj.props))
+	for i, prop := range obj.props {
+		key, ok := prop.key.(*ident)
+		if !ok || prop.spread || prop.computed {
+			entries[i] = c.print(prop)
+			continue
+		}
+		name, value := c.attributeName(el, key.name), c.print(prop.value)
+		if name == value {
+			entries[i] = name
+		} else {
+			entries[i] = name + ": " + value
+		}
+	}
+	if len(entries) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
+
+// Convertir comentarios JSX {/* ... */} en comentarios HTML
+func (c *converter) replaceComments(container *jsxExprContainer) string {
+	inner := strings.TrimSpace(c.src[container.start+1 : container.end-1])
+	var lines []string
+	for _, line := range strings.Split(inner, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "//")
+		line = strings.TrimPrefix(line, "/*")
+		line = strings.TrimSuffix(line, "*/")
+		line = strings.TrimPrefix(strings.TrimSpace(line), "* ")
+		if line = strings.TrimSpace(line); line != "" && line != "*" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "<!-- " + strings.Join(lines, " ") + " -->"
+}
+
+// Expresión en posición de hijo: bucles, condicionales o interpolación
+func (c *converter) printContainer(expr node, start int, fallback string) string {
+	expr = unparen(expr)
+	if text, ok := c.replaceLoops(expr, start); ok {
+		return text
+	}
+	if text, ok := c.replaceConditionals(expr, start); ok {
+		return text
+	}
+	if containsJSX(expr) {
+		c.warn(expr, CodeUnhandledJSX, "expresión JSX no convertida; se copió sin cambios")
+	}
+	return fallback
+}
+
+// items.map((item, i) => <li key={item.id}>...</li>) -> {#each items as item, i (item.id)}
+func (c *converter) replaceLoops(expr node, start int) (string, bool) {
+	call, ok := expr.(*callExpr)
+	if !ok || len(call.args) == 0 {
+		return "", false
+	}
+	member, ok := call.callee.(*memberExpr)
+	if !ok || member.property != "map" {
+		return "", false
+	}
+	fn, ok := unparen(call.args[0]).(*function)
+	if !ok || len(fn.params) == 0 || len(fn.params) > 2 {
+		return "", false
+	}
+	body := jsxResult(fn)
+	if body == nil {
+		return "", false
+	}
+
+	collection := c.print(member.object)
+	item := c.print(fn.params[0].target)
+	indexPart := ""
+	if len(fn.params) > 1 {
+		indexPart = ", " + c.print(fn.params[1].target)
+	}
+
+	// Buscar key y eliminarla del JSX
+	keyPart := ""
+	if el, ok := body.(*jsxElement); ok {
+		for _, a := range el.attrs {
+			attr, ok := a.(*jsxAttr)
+			if !ok || attr.name != "key" || attr.value == nil {
+				continue
+			}
+			c.skip[attr] = true
+			key := nodeText(c.src, attr.value)
+			if container, ok := attr.value.(*jsxExprContainer); ok && container.expr != nil {
+				key = c.print(container.expr)
+			}
+			keyPart = fmt.Sprintf(" (%s)", key)
+		}
+	}
+
+	indent := lineIndent(c.src, start)
+	var b strings.Builder
+	fmt.Fprintf(&b, "{#each %s as %s%s%s}\n", collection, item, indexPart, keyPart)
+	b.WriteString(c.blockContent(body, indent))
+	b.WriteString(indent + "{/each}")
+	return b.String(), true
+}
+
+// JSX que devuelve una función: arrow con cuerpo JSX o un bloque con un único return
+func jsxResult(fn *function) node {
+	if fn.body == nil {
+		if isJSX(fn.expr) {
+			return unparen(fn.expr)
+		}
+		return nil
+	}
+	if len(fn.body.body) != 1 {
+		return nil
+	}
+	if ret, ok := fn.body.body[0].(*returnStmt); ok && isJSX(ret.arg) {
+		return unparen(ret.arg)
+	}
+	return nil
+}
+
+// Rama de un bloque {#if}
+type ifBranch struct {
+	cond string
+	body node
+}
+
+func (c *converter) replaceConditionals(expr node, start int) (string, bool) {
+	var branches []ifBranch
+	var elseBody node
+
+	switch e := expr.(type) {
+	case *binaryExpr:
+		// 1. cond && <Elemento />
+		if _, isRender := c.renderTag(e.right); e.op != "&&" || (!isJSX(e.right) && !isRender) {
+			return "", false
+		}
+		branches = append(branches, ifBranch{c.print(e.left), unparen(e.right)})
+	case *conditionalExpr:
+		// 2. Ternario, con encadenamiento de cond ? a : otra ? b : c
+		if !containsJSXBranch(e) {
+			return "", false
+		}
+		for {
+			branches = append(branches, ifBranch{c.print(e.test), unparen(e.cons)})
+			next, ok := unparen(e.alt).(*conditionalExpr)
+			if !ok || !containsJSXBranch(next) {
+				elseBody = unparen(e.alt)
+				break
+			}
+			e = next
+		}
+	default:
+		return "", false
+	}
+
+	indent := lineIndent(c.src, start)
+	var b strings.Builder
+	for i, branch := range branches {
+		if i == 0 {
+			fmt.Fprintf(&b, "{#if %s}\n", branch.cond)
+		} else {
+			fmt.Fprintf(&b, "%s{:else if %s}\n", indent, branch.cond)
+		}
+		b.WriteString(c.blockContent(branch.body, indent))
+	}
+	if elseBody != nil && !isEmptyValue(elseBody) {
+		b.WriteString(indent + "{:else}\n")
+		b.WriteString(c.blockContent(elseBody, indent))
+	}
+	b.WriteString(indent + "{/if}")
+	return b.String(), true
+}
+
+func containsJSXBranch(e *conditionalExpr) bool {
+	if isJSX(e.cons) || isJSX(e.alt) {
+		return true
+	}
+	if next, ok := unparen(e.alt).(*conditionalExpr); ok {
+		return containsJSXBranch(next)
+	}
+	return false
+}
+
+// Valores que React no renderiza: null, undefined y false
+func isEmptyValue(n node) bool {
+	switch v := unparen(n).(type) {
+	case *literal:
+		return v.kind == litNull || v.raw == "false"
+	case *ident:
+		return v.name == "undefined"
+	}
+	return false
+}
+
+// Contenido de un bloque {#each}/{#if}, indentado un nivel más que el bloque
+func (c *converter) blockContent(body node, indent string) string {
+	if isEmptyValue(body) {
+		return ""
+	}
+	inner := indent + "  "
+	start := c.contentStart(body)
+
+	text, isRender := c.renderTag(body)
+	if isJSX(body) {
+		text = c.print(body)
+	} else if !isRender {
+		text = "{" + c.print(body) + "}"
+	}
+	text = strings.TrimSpace(reindent(text, columnOf(c.src, start), len(inner)))
+	return inner + text + "\n"
+}
+
+// Posición donde empieza el contenido visible de un nodo; en los fragmentos
+// es la de su primer hijo, ya que la etiqueta desaparece
+func (c *converter) contentStart(n node) int {
+	var kids []node
+	switch el := n.(type) {
+	case *jsxFragment:
+		kids = el.children
+	case *jsxElement:
+		if c.isTransparent(el) {
+			kids = el.children
+		}
+	}
+	for _, kid := range kids {
+		start, end := kid.bounds()
+		text := c.src[start:end]
+		if trimmed := strings.TrimLeft(text, " \t\r\n"); trimmed != "" {
+			return start + len(text) - len(trimmed)
+		}
+	}
+	start, _ := n.bounds()
+	return start
+}
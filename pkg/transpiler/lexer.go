@@ -0,0 +1,343 @@
+package transpiler
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tipos de token producidos por el lexer
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokTemplate
+	tokRegex
+	tokPunct
+	tokJSXText
+)
+
+// Token léxico con su posición en el código fuente
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+	end  int
+	nl   bool   // hay un salto de línea antes del token
+	subs []span // expresiones ${...} de un template literal
+}
+
+// Puntuadores ordenados de mayor a menor longitud. El '>' nunca se combina
+// en el lexer para que los genéricos anidados (Array<Array<T>>) funcionen;
+// el parser de expresiones reconstruye >>, >= y compañía cuando los necesita.
+var punctuators = []string{
+	"...", "===", "!==", "**=", "<<=", "&&=", "||=", "??=",
+	"==", "!=", "<=", "<<", "&&", "||", "??", "?.", "++", "--",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "**", "=>",
+	"{", "}", "(", ")", "[", "]", ";", ",", "<", ">", "+", "-", "*", "/",
+	"%", "&", "|", "^", "!", "~", "?", ":", "=", ".", "@",
+}
+
+// Puntuadores agrupados por su primer byte, conservando el orden anterior
+var punctuatorsByByte = func() (table [256][]string) {
+	for _, punct := range punctuators {
+		table[punct[0]] = append(table[punct[0]], punct)
+	}
+	return table
+}()
+
+// Palabras clave tras las cuales una '/' inicia una expresión regular
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// Indica si después del token puede empezar una expresión regular
+func allowsRegex(prev token) bool {
+	switch prev.kind {
+	case tokEOF:
+		return true
+	case tokIdent:
+		return regexKeywords[prev.val]
+	case tokPunct:
+		// Tras a++ o a-- viene un operador: a++ / 2
+		return prev.val != ")" && prev.val != "]" && prev.val != "}" && prev.val != "++" && prev.val != "--"
+	case tokJSXText:
+		return true
+	}
+	return false
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || r == '#' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Saltar espacios y comentarios; devuelve la nueva posición y si hubo salto de línea
+func (p *parser) skipTrivia(pos int) (int, bool) {
+	nl := false
+	for pos < p.limit {
+		c := p.src[pos]
+		switch {
+		case c == '\n':
+			nl = true
+			pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			pos++
+		case c == '/' && pos+1 < p.limit && p.src[pos+1] == '/':
+			for pos < p.limit && p.src[pos] != '\n' {
+				pos++
+			}
+		case c == '/' && pos+1 < p.limit && p.src[pos+1] == '*':
+			end := strings.Index(p.src[pos+2:p.limit], "*/")
+			if end == -1 {
+				p.fail(pos, "comentario de bloque sin cerrar")
+			}
+			if strings.Contains(p.src[pos:pos+2+end], "\n") {
+				nl = true
+			}
+			pos += end + 4
+		default:
+			if c >= utf8.RuneSelf {
+				r, size := utf8.DecodeRuneInString(p.src[pos:])
+				if unicode.IsSpace(r) {
+					if r == '\u2028' || r == '\u2029' {
+						nl = true
+					}
+					pos += size
+					continue
+				}
+			}
+			return pos, nl
+		}
+	}
+	return pos, nl
+}
+
+// Escanear el siguiente token de JavaScript/TypeScript a partir de pos
+func (p *parser) scan(pos int, regexOK bool) token {
+	pos, nl := p.skipTrivia(pos)
+	tok := p.scanAt(pos, regexOK)
+	tok.nl = nl
+	return tok
+}
+
+func (p *parser) scanAt(pos int, regexOK bool) token {
+	if pos >= p.limit {
+		return token{kind: tokEOF, pos: p.limit, end: p.limit}
+	}
+
+	c := p.src[pos]
+	r, size := utf8.DecodeRuneInString(p.src[pos:])
+
+	switch {
+	case isIdentStart(r) || c == '\\':
+		end := pos + size
+		for end < p.limit {
+			r, size := utf8.DecodeRuneInString(p.src[end:])
+			if !isIdentPart(r) {
+				break
+			}
+			end += size
+		}
+		return p.makeToken(tokIdent, pos, end)
+	case isDigit(c) || (c == '.' && pos+1 < p.limit && isDigit(p.src[pos+1])):
+		return p.makeToken(tokNumber, pos, p.scanNumber(pos))
+	case c == '"' || c == '\'':
+		return p.makeToken(tokString, pos, p.scanString(pos))
+	case c == '`':
+		return p.scanTemplate(pos)
+	case c == '/' && regexOK:
+		return p.makeToken(tokRegex, pos, p.scanRegex(pos))
+	}
+
+	for _, punct := range punctuatorsByByte[c] {
+		if strings.HasPrefix(p.src[pos:p.limit], punct) {
+			// "?." seguido de un dígito es un ternario: a?.5:b
+			if punct == "?." && pos+2 < p.limit && isDigit(p.src[pos+2]) {
+				continue
+			}
+			return p.makeToken(tokPunct, pos, pos+len(punct))
+		}
+	}
+
+	p.fail(pos, "carácter inesperado %q", r)
+	return token{}
+}
+
+func (p *parser) makeToken(kind tokenKind, pos, end int) token {
+	return token{kind: kind, val: p.src[pos:end], pos: pos, end: end}
+}
+
+func (p *parser) scanNumber(pos int) int {
+	end := pos
+	if p.src[end] == '0' && end+1 < p.limit && strings.ContainsRune("xXoObB", rune(p.src[end+1])) {
+		end += 2
+	}
+	for end < p.limit {
+		c := p.src[end]
+		switch {
+		case isDigit(c) || c == '_' || c == '.' || c == 'n' ||
+			(c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+			end++
+		case (c == '+' || c == '-') && (p.src[end-1] == 'e' || p.src[end-1] == 'E'):
+			end++
+		default:
+			return end
+		}
+	}
+	return end
+}
+
+func (p *parser) scanString(pos int) int {
+	quote := p.src[pos]
+	end := pos + 1
+	for end < p.limit {
+		switch p.src[end] {
+		case '\\':
+			end += 2
+			continue
+		case '\n':
+			p.fail(pos, "cadena sin cerrar")
+		case quote:
+			return end + 1
+		}
+		end++
+	}
+	p.fail(pos, "cadena sin cerrar")
+	return end
+}
+
+func (p *parser) scanRegex(pos int) int {
+	end := pos + 1
+	inClass := false
+	for end < p.limit {
+		switch p.src[end] {
+		case '\\':
+			end += 2
+			continue
+		case '\n':
+			p.fail(pos, "expresión regular sin cerrar")
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				end++
+				for end < p.limit && isIdentPart(rune(p.src[end])) {
+					end++
+				}
+				return end
+			}
+		}
+		end++
+	}
+	p.fail(pos, "expresión regular sin cerrar")
+	return end
+}
+
+// Escanear un template literal completo, registrando las expresiones ${...}
+func (p *parser) scanTemplate(pos int) token {
+	var subs []span
+	end := pos + 1
+	for end < p.limit {
+		switch {
+		case p.src[end] == '\\':
+			end += 2
+			continue
+		case p.src[end] == '`':
+			tok := p.makeToken(tokTemplate, pos, end+1)
+			tok.subs = subs
+			return tok
+		case strings.HasPrefix(p.src[end:p.limit], "${"):
+			start := end + 2
+			depth := 0
+			prev := token{kind: tokPunct, val: "{", end: start}
+			for {
+				tok := p.scan(prev.end, allowsRegex(prev))
+				if tok.kind == tokEOF {
+					p.fail(pos, "template literal sin cerrar")
+				}
+				if tok.kind == tokPunct && tok.val == "{" {
+					depth++
+				} else if tok.kind == tokPunct && tok.val == "}" {
+					if depth == 0 {
+						subs = append(subs, span{start, tok.pos})
+						end = tok.end
+						break
+					}
+					depth--
+				}
+				prev = tok
+			}
+			continue
+		}
+		end++
+	}
+	p.fail(pos, "template literal sin cerrar")
+	return token{}
+}
+
+// Escanear un hijo JSX: texto literal hasta el siguiente '{' o '<'
+func (p *parser) scanJSXChild(pos int) token {
+	if pos >= p.limit {
+		return token{kind: tokEOF, pos: p.limit, end: p.limit}
+	}
+	if c := p.src[pos]; c == '{' || c == '<' {
+		return p.makeToken(tokPunct, pos, pos+1)
+	}
+	end := pos
+	for end < p.limit && p.src[end] != '{' && p.src[end] != '<' {
+		end++
+	}
+	return p.makeToken(tokJSXText, pos, end)
+}
+
+// Escanear un token dentro de una etiqueta JSX, donde los nombres admiten '-'
+// y las cadenas no tienen secuencias de escape
+func (p *parser) scanJSXTag(pos int) token {
+	pos, nl := p.skipTrivia(pos)
+	if pos >= p.limit {
+		return token{kind: tokEOF, pos: p.limit, end: p.limit, nl: nl}
+	}
+
+	var tok token
+	c := p.src[pos]
+	r, size := utf8.DecodeRuneInString(p.src[pos:])
+	switch {
+	case isIdentStart(r):
+		end := pos + size
+		for end < p.limit {
+			r, size := utf8.DecodeRuneInString(p.src[end:])
+			if !isIdentPart(r) && r != '-' {
+				break
+			}
+			end += size
+		}
+		tok = p.makeToken(tokIdent, pos, end)
+	case c == '"' || c == '\'':
+		end := strings.IndexByte(p.src[pos+1:p.limit], c)
+		if end == -1 {
+			p.fail(pos, "cadena sin cerrar en atributo JSX")
+		}
+		tok = p.makeToken(tokString, pos, pos+end+2)
+	case strings.ContainsRune("/>={}.:<", rune(c)):
+		tok = p.makeToken(tokPunct, pos, pos+1)
+	default:
+		p.fail(pos, "carácter inesperado %q en etiqueta JSX", r)
+	}
+	tok.nl = nl
+	return tok
+}
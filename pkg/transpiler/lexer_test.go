@@ -0,0 +1,92 @@
+package transpiler
+
+import (
+	"slices"
+	"testing"
+)
+
+// Tokens del código con el mismo criterio de expresiones regulares que el
+// parser: según el token anterior
+func lex(src string) (toks []token, err error) {
+	p := &parser{src: src, limit: len(src)}
+	defer p.recover(&err)
+	p.tok = p.scan(0, true)
+	for p.tok.kind != tokEOF {
+		toks = append(toks, p.tok)
+		p.next()
+	}
+	return toks, nil
+}
+
+func TestLexRegexOrDivision(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string // valores de los tokens; las expresiones regulares entre « »
+	}{
+		{"a / b / c", []string{"a", "/", "b", "/", "c"}},
+		{"x = /ab+c/g.test(s)", []string{"x", "=", "«/ab+c/g»", ".", "test", "(", "s", ")"}},
+		{"f(a) / 2", []string{"f", "(", "a", ")", "/", "2"}},
+		{"list[0] / 2", []string{"list", "[", "0", "]", "/", "2"}},
+		{"a++ / 2", []string{"a", "++", "/", "2"}},
+		{"return /x/i", []string{"return", "«/x/i»"}},
+		{"typeof /x/", []string{"typeof", "«/x/»"}},
+		{"s.split(/[,;]/)", []string{"s", ".", "split", "(", "«/[,;]/»", ")"}},
+		{"/[/]/.test(p)", []string{"«/[/]/»", ".", "test", "(", "p", ")"}},
+		{"total /= 2", []string{"total", "/=", "2"}},
+	}
+	for _, tt := range tests {
+		toks, err := lex(tt.src)
+		if err != nil {
+			t.Errorf("%q: %v", tt.src, err)
+			continue
+		}
+		var got []string
+		for _, tok := range toks {
+			if tok.kind == tokRegex {
+				got = append(got, "«"+tok.val+"»")
+			} else {
+				got = append(got, tok.val)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: %q, se esperaba %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestLexTemplate(t *testing.T) {
+	tests := []struct {
+		src  string
+		subs []string // expresiones ${...}
+	}{
+		{"`hola`", nil},
+		{"`a${b}c${d}`", []string{"b", "d"}},
+		{"`${a ? `x${b}` : 'y'}`", []string{"a ? `x${b}` : 'y'"}},
+		{"`${'}'}x`", []string{"'}'"}},
+		{"`${{ a: 1 }.a}`", []string{"{ a: 1 }.a"}},
+		{"`a\\`b${c}`", []string{"c"}},
+		{"`${a / b}${/}/.source}`", []string{"a / b", "/}/.source"}},
+	}
+	for _, tt := range tests {
+		toks, err := lex(tt.src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		if len(toks) != 1 || toks[0].kind != tokTemplate {
+			t.Errorf("%s: se esperaba un único template literal, se obtuvieron %d tokens", tt.src, len(toks))
+			continue
+		}
+		var subs []string
+		for _, s := range toks[0].subs {
+			subs = append(subs, tt.src[s.start:s.end])
+		}
+		if !slices.Equal(subs, tt.subs) {
+			t.Errorf("%s: %q, se esperaba %q", tt.src, subs, tt.subs)
+		}
+	}
+
+	if _, err := lex("`a${b"); err == nil {
+		t.Error("se esperaba un error con un template literal sin cerrar")
+	}
+}
@@ -2,565 +2,1896 @@ package transpiler
 
 import (
 	"fmt"
-	"regexp"
+	"maps"
+	"slices"
 	"strings"
+	"unicode"
 )
 
-// Separar JSX del código JavaScript/TypeScript
-func (t *Transpiler) separateJSXFromCode(code string) (string, string, error) {
-	start := strings.Index(code, "return (")
-	if start == -1 {
-		return code, "", nil
-	}
+// Estado de una conversión: el código fuente, su AST y las tablas que usan
+// las reescrituras del script y del markup. Se crea uno por componente.
+type converter struct {
+	filename      string
+	src           string
+	file          *program
+	diagnostics   []Diagnostic
+	code          rewriter
+	setters       map[string]string // setter -> variable de estado
+	stateTypes    map[string]string // variable de estado -> tipo
+	hooks         map[string]bool   // hooks personalizados que se llaman en el archivo
+	hookObjects   map[string]bool   // variables generadas para el resultado de un hook
+	refs          map[string]bool   // refs de useRef, cuyo .current se elimina
+	contexts      map[string]*contextRef
+	svelteImports map[string]bool       // funciones de svelte que necesita el script
+	renames       map[string]string     // identificadores a renombrar (prev -> count)
+	scopes        *scopes               // ámbitos de los identificadores del archivo
+	locals        map[scopedName]string // variables locales renombradas: parámetros de actualización (prev -> count) y las que ocultan un estado
+	moduleNames   map[string]string     // nombres que declara el código del módulo -> type, enum o value
+	shared        map[string]string     // moduleNames del principal, del que importan los demás componentes
+	importsShared bool                  // el componente importa del <script module> del principal
+	skip          map[node]bool         // nodos que se omiten al imprimir
+	consumed      map[node]bool         // sentencias y declaraciones ya convertidas
+	decl          *componentDecl        // componente que se convierte, si lo hay
+	main          *componentDecl        // componente principal del archivo
+	siblings      map[string]*sibling
+	providers     []provider      // Provider de contextos del componente
+	markup        node            // JSX devuelto por el componente
+	propsParam    string          // parámetro de las props cuyos accesos props.x se reescriben como x
+	restProp      string          // variable con el resto de props
+	snippetProps  map[string]bool // props que reciben contenido y se renderizan con {@render}
+	numbers       map[string]bool // props y estados numéricos, que llevan px en los estilos
+	bindings      map[node]string // atributos de inputs controlados -> bind:value/bind:checked
+	earlyReturns  []earlyReturn   // retornos anteriores al JSX principal
+	todos         []TodoDefinition
+}
 
-	start += len("return (")
-	count := 1
-	end := start
+// Retorno anticipado del componente, que se convierte en una rama {#if}. Si
+// la rama tiene retornos anidados, branches son sus {#if} y markup su {:else}
+type earlyReturn struct {
+	test     node
+	markup   node // nil en return;
+	branches []earlyReturn
+}
 
-	for end < len(code) && count > 0 {
-		if code[end] == '(' {
-			count++
-		} else if code[end] == ')' {
-			count--
-		}
-		end++
-	}
+// Componente declarado en el archivo
+type componentDecl struct {
+	name       string
+	fn         *function
+	stmt       node   // sentencia de nivel superior que lo declara
+	propsType  string // tipo de las props declarado fuera de la función: React.FC<P>, forwardRef<T, P>
+	forwardRef bool   // el segundo parámetro es la ref de forwardRef
+	refType    string // tipo del elemento de la ref
+}
 
-	if count != 0 {
-		return "", "", fmt.Errorf("no se pudo emparejar los paréntesis del JSX")
+func newConverter(filename, src string, file *program) *converter {
+	c := &converter{
+		filename:      filename,
+		src:           src,
+		file:          file,
+		setters:       make(map[string]string),
+		stateTypes:    make(map[string]string),
+		hooks:         make(map[string]bool),
+		hookObjects:   make(map[string]bool),
+		refs:          make(map[string]bool),
+		contexts:      make(map[string]*contextRef),
+		svelteImports: make(map[string]bool),
+		snippetProps:  make(map[string]bool),
+		numbers:       make(map[string]bool),
+		bindings:      make(map[node]string),
+		renames:       make(map[string]string),
+		scopes:        analyzeScopes(file),
+		locals:        make(map[scopedName]string),
+		moduleNames:   make(map[string]string),
+		skip:          make(map[node]bool),
+		consumed:      make(map[node]bool),
 	}
+	c.code = rewriter{src: src, replace: c.replace}
+	return c
+}
 
-	jsxContent := strings.TrimSpace(code[start : end-1])
-	jsCode := strings.TrimSpace(code[:start-len("return (")] + code[end:])
-	return jsCode, jsxContent, nil
+// Imprimir un nodo aplicando todas las reescrituras
+func (c *converter) print(n node) string {
+	return c.code.print(n)
 }
 
-// Parsear el código React (JavaScript/TypeScript)
-func (t *Transpiler) parseReactCode(jsCode string) (*ReactComponent, error) {
-	component := &ReactComponent{}
+// Parsear el código React a partir del árbol sintáctico
+func (c *converter) parseReactCode() *ReactComponent {
+	component := &ReactComponent{Name: "Component"}
+	decl := c.decl
+	if decl == nil {
+		decl = c.findComponent()
+		c.decl = decl
+	}
+	c.consumeSiblings()
+
+	var body []node
+	if decl != nil {
+		component.Name = decl.name
+		if decl.fn.body != nil {
+			body = decl.fn.body.body
+		}
+	}
+
+	// Localizar contextos y hooks antes de los imports, que pueden necesitar
+	// getX/setX o apuntar a un módulo .svelte.ts
+	c.findContexts()
+	c.findHooks()
 
 	// Extraer imports
-	component.Imports = t.extractImports(jsCode)
+	component.Imports = c.extractImports()
+
+	// Extraer props
+	component.Props = c.extractProps(decl, body)
+	c.extractSnippetProps(component.Props)
+	if component.PropsExtends = c.propsExtends(decl, component.Props); component.PropsExtends != "" {
+		component.Imports = append(component.Imports, elementsImport(component.PropsExtends))
+	}
+
+	// Extraer contextos
+	component.Contexts, component.ContextUsages = c.extractContexts(decl, body)
+
+	// Extraer states (antes que el resto, para poder convertir los setters)
+	component.States = c.extractStates(body)
+	c.registerStates(component.States)
+	component.Reducers = c.extractReducers(body)
+
+	// Un archivo sin componente que declara hooks use* es un módulo de runes;
+	// los hooks de un archivo con componente se convierten igual y se declaran
+	// en su script. Se extraen antes que las llamadas del componente, cuyas
+	// desestructuraciones renombran variables que el hook también puede usar.
+	// El código del módulo lo emite una sola vez el componente principal
+	if !c.isSibling() {
+		component.Hooks = c.extractHooks()
+
+		// Tipos del módulo que no describen props: los usan las props, los
+		// estados y los demás componentes del archivo
+		component.Types = c.extractTypes()
+	}
 
-	// Extraer props usando regex
-	component.Props = t.extractProps(jsCode)
+	// Extraer refs
+	component.Refs = c.extractRefs(decl, body)
+	for _, ref := range component.Refs {
+		c.refs[ref.Name] = true
+	}
 
-	// Extraer states
-	component.States = t.extractStates(jsCode)
+	// Extraer valores derivados de props y states
+	reactive := make(map[string]bool)
+	for _, prop := range component.Props {
+		reactive[prop.Name] = !prop.InRest
+		c.numbers[prop.Name] = prop.Type == "number"
+	}
+	if c.propsParam != "" {
+		reactive[c.propsParam] = true
+	}
+	for _, state := range component.States {
+		reactive[state.Name] = true
+		c.numbers[state.Name] = state.Type == "number" || isNumberLiteral(state.InitialValue)
+	}
+	for _, reducer := range component.Reducers {
+		reactive[reducer.Name] = true
+	}
+	for _, name := range reducerTargets(body) {
+		reactive[name] = true
+	}
+	// Los contextos provistos con un objeto exponen sus valores con getters
+	for _, name := range contextTargets(body) {
+		reactive[name] = true
+	}
+	component.HookUsages = c.extractHookUsages(body, reactive)
+	component.Derived = c.extractDerived(body, reactive)
+	component.ContextUsages = append(component.ContextUsages, c.providerUsages(reactive)...)
 
 	// Extraer effects
-	component.Effects = t.extractEffects(jsCode)
+	component.Effects = c.extractEffects(body, reactive)
 
 	// Extraer funciones (excluyendo el componente principal)
-	component.Functions = t.extractFunctions(jsCode)
+	component.ModuleFunctions, component.Functions = c.extractFunctions(decl, body)
 
-	// Extraer nombre del componente
-	component.Name = t.extractComponentName(jsCode)
+	// Las constantes del módulo y las variables del componente que no
+	// dependen de valores reactivos se conservan tal cual. Un archivo sin
+	// componente solo las necesita si es un módulo de hooks o contextos
+	if !c.isSibling() && (decl != nil || len(component.Hooks) > 0 || len(component.Contexts) > 0) {
+		component.Constants = c.extractConstants()
+	}
+	component.Module = decl != nil && len(component.Contexts) > 0
+	c.registerModuleNames(component)
+	component.Variables = c.extractVariables(body)
+
+	// Localizar el JSX devuelto
+	if decl != nil {
+		c.markup = c.extractMarkup(decl.fn)
+	}
+	if c.markup != nil {
+		component.JSXContent = nodeText(c.src, c.markup)
+	}
 
-	return component, nil
+	// Los demás componentes del archivo, una vez conocidos los tipos de las props
+	component.Imports = append(component.Imports, c.siblingImports(component)...)
+
+	// Avisar de todo lo que no se ha podido convertir
+	c.reportUnconverted(decl, body)
+
+	for name := range c.svelteImports {
+		component.SvelteImports = append(component.SvelteImports, name)
+	}
+	slices.Sort(component.SvelteImports)
+
+	return component
 }
 
-// Extraer imports
-func (t *Transpiler) extractImports(code string) []string {
-	importRegex := regexp.MustCompile(`import\s+.*?from\s+['"].*?['"]`)
-	matches := importRegex.FindAllString(code, -1)
+// Localizar el componente: una función con nombre en mayúscula, preferiblemente
+// una que devuelva JSX
+func (c *converter) findComponent() *componentDecl {
+	var candidates []*componentDecl
+	var defaultExport *componentDecl
+	var defaultName string
+	var defaultWrapper componentWrapper
+
+	for _, stmt := range c.file.body {
+		decl := stmt
+		exp, exported := stmt.(*exportDecl)
+		if exported && exp.decl != nil {
+			decl = exp.decl
+		}
 
-	var cleanImports []string
-	for _, match := range matches {
-		isReactImport := strings.Contains(match, "react") || strings.Contains(match, "React")
-		isNextImport := false
-		if strings.Contains(match, "next") || strings.Contains(match, "Next") {
-			nextImportRegex := regexp.MustCompile(`from\s+['"]next[\/\w-]*['"]`)
-			isNextImport = nextImportRegex.MatchString(match)
+		// export default memo(Card) o export default forwardRef((props, ref) => ...)
+		if exported && exp.isDefault && exp.expr != nil {
+			inner, wrapper := unwrapComponent(exp.expr)
+			switch inner := inner.(type) {
+			case *ident:
+				defaultName, defaultWrapper = inner.name, wrapper
+			case *function:
+				name := inner.name
+				if name == "" {
+					name = "Component"
+				}
+				defaultExport = wrapper.apply(&componentDecl{name: name, fn: inner, stmt: stmt})
+			}
+			continue
 		}
 
-		if !isReactImport && !isNextImport {
-			cleanImports = append(cleanImports, match)
+		switch d := decl.(type) {
+		case *funcDecl:
+			if d.fn.body == nil {
+				continue
+			}
+			candidate := &componentDecl{name: d.name, fn: d.fn, stmt: stmt}
+			if exported && exp.isDefault && defaultExport == nil && !isHookName(d.name) {
+				defaultExport = candidate
+			}
+			if isComponentName(d.name) {
+				candidates = append(candidates, candidate)
+			}
+		case *varDecl:
+			for _, v := range d.decls {
+				if candidate, ok := componentDeclarator(v, stmt); ok {
+					candidates = append(candidates, candidate)
+				}
+			}
 		}
 	}
 
-	return cleanImports
+	// El envoltorio de export default memo(forwardRef(Card)) se aplica a Card
+	if defaultName != "" {
+		for _, candidate := range candidates {
+			if candidate.name == defaultName {
+				defaultExport = defaultWrapper.apply(candidate)
+			}
+		}
+	}
+
+	if defaultExport != nil && returnsJSX(defaultExport.fn) {
+		return defaultExport
+	}
+	for _, candidate := range candidates {
+		if returnsJSX(candidate.fn) {
+			return candidate
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return defaultExport
 }
-func (t *Transpiler) extractProps(code string) []PropDefinition {
-	var props []PropDefinition
-	propsMap := make(map[string]bool) // Para evitar duplicados
 
-	// 1. Destructuring directo en parámetros de la función
-	paramRegex := regexp.MustCompile(`function\s+\w+\s*\(\s*{\s*([^}]+)\s*}\s*(?::\s*(\w+Props))?\s*\)`)
-	paramMatches := paramRegex.FindStringSubmatch(code)
+func isComponentName(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
 
-	if len(paramMatches) > 1 {
-		propsList := strings.Split(paramMatches[1], ",")
-		for _, prop := range propsList {
-			prop = strings.TrimSpace(prop)
+func isJSX(n node) bool {
+	switch unparen(n).(type) {
+	case *jsxElement, *jsxFragment:
+		return true
+	}
+	return false
+}
 
-			if prop == "" {
+// Indica si la función devuelve JSX (sin entrar en funciones anidadas)
+func returnsJSX(fn *function) bool {
+	if fn.body == nil {
+		return isJSX(fn.expr)
+	}
+	found := false
+	walk(fn.body, func(n node) bool {
+		switch n := n.(type) {
+		case *function:
+			return false
+		case *returnStmt:
+			if isJSX(n.arg) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// JSX devuelto por el componente. Los retornos anticipados anteriores
+// (if (loading) return <Spinner />), con sus else if y else, se guardan en
+// c.earlyReturns
+func (c *converter) extractMarkup(fn *function) node {
+	if fn.body == nil {
+		return unparen(fn.expr)
+	}
+	c.earlyReturns = nil
+	for _, stmt := range fn.body.body {
+		switch s := stmt.(type) {
+		case *returnStmt:
+			c.consumed[s] = true
+			return returnedMarkup(s)
+		case *ifStmt:
+			if !containsReturn(s) {
+				continue
+			}
+			branches, rest, ok := c.ifChain(s)
+			if !ok {
+				c.dropReturn(s)
 				continue
 			}
+			c.consumed[s] = true
+			c.earlyReturns = append(c.earlyReturns, branches...)
+			// Con else, todas las ramas devuelven y lo que sigue no se ejecuta
+			if rest != nil {
+				c.earlyReturns = append(c.earlyReturns, rest.branches...)
+				return rest.markup
+			}
+		default:
+			if containsReturn(s) {
+				c.dropReturn(s)
+			}
+		}
+	}
+	return nil
+}
 
-			// Manejar default value
-			if strings.Contains(prop, "=") {
-				parts := strings.SplitN(prop, "=", 2)
-				propName := strings.TrimSpace(parts[0])
-				defaultValue := strings.TrimSpace(parts[1])
-				props = append(props, PropDefinition{
-					Name:         propName,
-					Type:         "any",
-					DefaultValue: defaultValue,
-					Optional:     true,
-				})
-				propsMap[propName] = true
-			} else {
-				props = append(props, PropDefinition{
-					Name:     prop,
-					Type:     "any",
-					Optional: false,
-				})
-				propsMap[prop] = true
+// Cadena if / else if / else cuyas ramas terminan con return. La rama else,
+// si la hay, se devuelve aparte
+func (c *converter) ifChain(ifs *ifStmt) ([]earlyReturn, *earlyReturn, bool) {
+	var branches []earlyReturn
+	for {
+		branch, ok := c.returnBody(ifs.cons)
+		if !ok {
+			return nil, nil, false
+		}
+		branch.test = ifs.test
+		branches = append(branches, branch)
+		switch alt := ifs.alt.(type) {
+		case nil:
+			return branches, nil, true
+		case *ifStmt:
+			ifs = alt
+		default:
+			rest, ok := c.returnBody(alt)
+			if !ok {
+				return nil, nil, false
 			}
+			return branches, &rest, true
 		}
 	}
+}
 
-	// 2. Destructuring de props dentro del cuerpo: const { x, y = z } = props;
-	propsRegex := regexp.MustCompile(`const\s*{\s*([^}]+)\s*}\s*=\s*props`)
-	matches := propsRegex.FindStringSubmatch(code)
+// Rama de un if que termina siempre con return, quizá tras otros if que
+// también devuelven: if (b) return <X />; return <Y />;
+func (c *converter) returnBody(body node) (earlyReturn, bool) {
+	stmts := []node{body}
+	if b, ok := body.(*block); ok {
+		stmts = b.body
+	}
+	var branch earlyReturn
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *emptyStmt:
+		case *returnStmt:
+			branch.markup = returnedMarkup(s)
+			return branch, true
+		case *ifStmt:
+			branches, rest, ok := c.ifChain(s)
+			if !ok {
+				return branch, false
+			}
+			branch.branches = append(branch.branches, branches...)
+			if rest != nil {
+				branch.branches = append(branch.branches, rest.branches...)
+				branch.markup = rest.markup
+				return branch, true
+			}
+		default:
+			// Otras sentencias no tienen equivalente en el markup
+			return branch, false
+		}
+	}
+	return branch, false
+}
 
-	if len(matches) > 1 {
-		propsList := strings.Split(matches[1], ",")
-		for _, prop := range propsList {
-			prop = strings.TrimSpace(prop)
+// Valor devuelto como markup; nil si no renderiza nada
+func returnedMarkup(ret *returnStmt) node {
+	if ret.arg == nil || isEmptyValue(ret.arg) {
+		return nil
+	}
+	return unparen(ret.arg)
+}
 
-			if prop == "" {
-				continue
-			}
+// Indica si la sentencia contiene un return, sin entrar en funciones anidadas
+func containsReturn(stmt node) bool {
+	found := false
+	walk(stmt, func(n node) bool {
+		switch n.(type) {
+		case *function:
+			return false
+		case *returnStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
 
-			if strings.Contains(prop, "=") {
-				parts := strings.SplitN(prop, "=", 2)
-				propName := strings.TrimSpace(parts[0])
-				defaultValue := strings.TrimSpace(parts[1])
-				if !propsMap[propName] {
-					props = append(props, PropDefinition{
-						Name:         propName,
-						Type:         "any",
-						DefaultValue: defaultValue,
-						Optional:     true,
-					})
-					propsMap[propName] = true
-				}
-			} else {
-				if !propsMap[prop] {
-					props = append(props, PropDefinition{
-						Name:     prop,
-						Type:     "any",
-						Optional: false,
-					})
-					propsMap[prop] = true
+// Error por un retorno del componente que no se puede expresar con {#if}
+func (c *converter) dropReturn(stmt node) {
+	c.consumed[stmt] = true
+	c.fail(stmt, CodeEarlyReturn, "retorno del componente que no se puede convertir en {#if}; se omitió")
+	c.todos[len(c.todos)-1].Source = strings.TrimSpace(nodeText(c.src, stmt))
+}
+
+// Extraer imports, descartando los de React y Next.js
+func (c *converter) extractImports() []string {
+	var cleanImports []string
+	for _, stmt := range c.file.body {
+		imp, ok := stmt.(*importDecl)
+		if !ok {
+			continue
+		}
+		c.consumed[imp] = true
+		if isFrameworkModule(imp.source) {
+			continue
+		}
+		text := strings.TrimSuffix(strings.TrimSpace(nodeText(c.src, imp)), ";")
+		if contextText, ok := c.contextImport(imp); ok {
+			text = contextText
+		}
+		// Los hooks del proyecto se convierten en módulos .svelte.ts
+		if c.importsHooks(imp) {
+			from := strings.LastIndex(text, imp.source)
+			text = text[:from] + hookModulePath(imp.source) + text[from+len(imp.source):]
+		}
+		cleanImports = append(cleanImports, text)
+	}
+	return cleanImports
+}
+
+// Declaraciones de tipos del módulo que no se han usado para las props
+func (c *converter) extractTypes() []string {
+	var types []string
+	for _, stmt := range c.file.body {
+		var name, kind string
+		switch d := unwrapExport(stmt).(type) {
+		case *interfaceDecl:
+			name, kind = d.name, "type"
+		case *typeAlias:
+			name, kind = d.name, "type"
+		case *enumDecl:
+			name, kind = d.name, "enum"
+		default:
+			continue
+		}
+		if !c.consumed[stmt] {
+			c.consumed[stmt] = true
+			c.moduleNames[name] = kind
+			types = append(types, strings.TrimSpace(nodeText(c.src, stmt)))
+		}
+	}
+	return types
+}
+
+// Constantes del módulo sin hooks ni JSX, que se copian sin cambios; en un
+// componente se omite el export
+func (c *converter) extractConstants() []string {
+	var constants []string
+	for _, stmt := range c.file.body {
+		code, ok := c.keepDeclaration(stmt, func(n node) string { return nodeText(c.src, n) })
+		if ok {
+			constants = append(constants, code)
+			for _, d := range unwrapExport(stmt).(*varDecl).decls {
+				for _, name := range boundNames(d.target) {
+					c.moduleNames[name] = "value"
 				}
 			}
 		}
 	}
+	return constants
+}
 
-	// 3. Interface o type definition: interface MyProps { a: string; b?: number }
-	interfaceRegex := regexp.MustCompile(`(?:interface|type)\s+(\w+Props)\s*{\s*([^}]+)\s*}`)
-	interfaceMatches := interfaceRegex.FindStringSubmatch(code)
+// Indica si el componente es uno de los secundarios del archivo, que
+// importa el código del módulo del principal en lugar de repetirlo
+func (c *converter) isSibling() bool {
+	return c.main != nil && c.decl != c.main
+}
+
+// Registrar las funciones, hooks y contextos que declara el código del módulo
+func (c *converter) registerModuleNames(component *ReactComponent) {
+	for _, fn := range component.ModuleFunctions {
+		c.moduleNames[fn.Name] = "value"
+	}
+	for _, hook := range component.Hooks {
+		c.moduleNames[hook.Name] = "value"
+	}
+	for _, ctx := range component.Contexts {
+		c.moduleNames[ctx.Name] = "value"
+		c.moduleNames[contextGetter(ctx.Name)] = "value"
+		c.moduleNames[contextSetter(ctx.Name)] = "value"
+	}
+}
 
-	if len(interfaceMatches) > 2 {
-		propsBody := interfaceMatches[2]
-		propLines := strings.Split(propsBody, "\n")
+// Variables del componente o de un hook que no se han convertido en valores
+// reactivos, con las reescrituras del script
+func (c *converter) extractVariables(body []node) []VariableDefinition {
+	var variables []VariableDefinition
+	for _, stmt := range body {
+		if code, ok := c.keepDeclaration(stmt, c.print); ok {
+			variables = append(variables, VariableDefinition{Code: code, Order: c.order(stmt)})
+		}
+	}
+	return variables
+}
+
+// Declaración de variables pendiente sin hooks ni JSX, impresa con print; si
+// otros declaradores ya se han convertido, solo los que quedan
+func (c *converter) keepDeclaration(stmt node, print func(node) string) (string, bool) {
+	// using libera el recurso al final del script, no tras cada render
+	vd, ok := unwrapExport(stmt).(*varDecl)
+	if !ok || c.consumed[stmt] || strings.HasSuffix(vd.kind, "using") {
+		return "", false
+	}
+	var pending []*declarator
+	for _, d := range vd.decls {
+		if _, isFn := unparen(d.init).(*function); !c.consumed[d] && !isFn && !containsJSX(d) && firstHook(d) == nil {
+			pending = append(pending, d)
+		}
+	}
+	if len(pending) == 0 {
+		return "", false
+	}
+
+	var code string
+	if len(pending) < len(vd.decls) {
+		decls := make([]string, len(pending))
+		for i, d := range pending {
+			decls[i] = print(d)
+		}
+		code = vd.kind + " " + strings.Join(decls, ", ")
+	} else {
+		c.consumed[stmt] = true
+		// El export solo se conserva en un módulo sin componente
+		var target node = vd
+		if c.decl == nil {
+			target = stmt
+		}
+		code = strings.TrimSpace(print(target))
+	}
+	for _, d := range pending {
+		c.consumed[d] = true
+	}
+	if !strings.HasSuffix(code, ";") {
+		code += ";"
+	}
+	return code, true
+}
 
-		for _, line := range propLines {
-			line = strings.TrimSpace(line)
-			if line == "" || strings.HasPrefix(line, "//") {
+func isFrameworkModule(source string) bool {
+	for _, module := range []string{"react", "react-dom", "next"} {
+		if source == module || strings.HasPrefix(source, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *converter) extractProps(decl *componentDecl, body []node) []PropDefinition {
+	var props []PropDefinition
+	propsMap := make(map[string]int) // Para evitar duplicados
+
+	addPattern := func(pat *objectPattern) {
+		for _, prop := range pat.props {
+			if id, ok := prop.value.(*ident); ok && prop.rest {
+				c.restProp = id.name
+				props = append(props, PropDefinition{Name: id.name, Type: "any", Rest: true})
+				continue
+			}
+			key, ok := prop.key.(*ident)
+			if prop.rest || prop.computed || !ok {
 				continue
 			}
+			if _, exists := propsMap[key.name]; exists {
+				continue
+			}
+			def := PropDefinition{Name: key.name, Type: "any"}
+			if prop.def != nil {
+				def.DefaultValue = c.print(prop.def)
+				def.Optional = true
+			}
+			propsMap[key.name] = len(props)
+			props = append(props, def)
+		}
+	}
 
-			// Ejemplo: name?: string;
-			propRegex := regexp.MustCompile(`^(\w+)(\??):\s*([^;]+);?$`)
-			propMatch := propRegex.FindStringSubmatch(line)
+	var first *param
+	if decl != nil && len(decl.fn.params) > 0 {
+		first = decl.fn.params[0]
+	}
 
-			if len(propMatch) > 3 {
-				name := propMatch[1]
-				optional := propMatch[2] == "?"
-				typ := strings.TrimSpace(propMatch[3])
+	// 1. Destructuring directo en parámetros de la función
+	if first != nil {
+		if pat, ok := first.target.(*objectPattern); ok {
+			addPattern(pat)
+		}
+	}
 
-				if !propsMap[name] {
-					props = append(props, PropDefinition{
-						Name:     name,
-						Type:     typ,
-						Optional: optional,
-					})
-					propsMap[name] = true
+	// 2. Destructuring de props dentro del cuerpo: const { x, y = z } = props;
+	if first != nil {
+		if propsIdent, ok := first.target.(*ident); ok {
+			for _, stmt := range body {
+				vd, ok := stmt.(*varDecl)
+				if !ok {
+					continue
+				}
+				for _, d := range vd.decls {
+					pat, isPattern := d.target.(*objectPattern)
+					init, isIdent := unparen(d.init).(*ident)
+					if isPattern && isIdent && init.name == propsIdent.name {
+						addPattern(pat)
+						c.consumed[d] = true
+					}
 				}
 			}
 		}
 	}
 
-	return props
-}
+	// 2b. Props leídas a través del parámetro: props.title pasa a ser la prop
+	// title. Si el objeto se usa entero se conserva como resto de props
+	var accessed []string
+	if first != nil {
+		if propsIdent, ok := first.target.(*ident); ok {
+			var whole bool
+			accessed, whole = c.propsAccesses(decl.fn, propsIdent.name)
+			if whole {
+				accessed = nil
+				c.restProp = propsIdent.name
+				props = append(props, PropDefinition{Name: propsIdent.name, Type: "any", Rest: true})
+			} else {
+				c.propsParam = propsIdent.name
+			}
+		}
+	}
 
-// Extraer states usando useState
-func (t *Transpiler) extractStates(code string) []StateDefinition {
-	var states []StateDefinition
+	// 3. Interface o type definition: interface MyProps { a: string; b?: number }
+	var members []*typeMember
+	if first != nil {
+		typ := first.typ
+		if typ == "" {
+			typ = decl.propsType
+		}
+		members = c.propsTypeMembers(typ)
+	}
+	for _, member := range members {
+		if i, exists := propsMap[member.name]; exists {
+			props[i].Type = member.typ
+			props[i].Optional = props[i].Optional || member.optional
+			continue
+		}
+		propsMap[member.name] = len(props)
+		props = append(props, PropDefinition{
+			Name:     member.name,
+			Type:     member.typ,
+			Optional: member.optional,
+			InRest:   slices.ContainsFunc(props, func(p PropDefinition) bool { return p.Rest }),
+		})
+	}
 
-	// Buscar useState hooks: const [stateName, setStateName] = useState(initialValue)
-	stateRegex := regexp.MustCompile(`const\s*\[\s*(\w+)\s*,\s*set\w+\s*\]\s*=\s*useState\s*\(\s*((?:[^()]|\([^()]*\))*)\s*\)`)
-	matches := stateRegex.FindAllStringSubmatch(code, -1)
+	for _, name := range accessed {
+		if _, exists := propsMap[name]; !exists {
+			propsMap[name] = len(props)
+			props = append(props, PropDefinition{Name: name, Type: "any"})
+		}
+	}
 
-	for _, match := range matches {
-		if len(match) > 2 {
-			states = append(states, StateDefinition{
-				Name:         match[1],
-				Type:         "any",
-				InitialValue: match[2],
-			})
+	// 4. La ref de forwardRef se convierte en una prop enlazable con bind:this
+	if decl != nil && decl.forwardRef && len(decl.fn.params) > 1 {
+		if id, ok := decl.fn.params[1].target.(*ident); ok {
+			typ := decl.refType
+			if typ == "" {
+				typ = "HTMLElement"
+			}
+			props = append(props, PropDefinition{Name: id.name, Type: typ, DefaultValue: "$bindable()", Optional: true})
 		}
 	}
 
-	return states
+	return props
 }
 
-// Extraer useEffect hooks
-func (t *Transpiler) extractEffects(code string) []EffectDefinition {
-	var effects []EffectDefinition
-
-	// Buscar useEffect: useEffect(() => { ... }, [dependencies])
-	effectRegex := regexp.MustCompile(`useEffect\s*\(\s*\(\s*\)\s*=>\s*{\s*([\s\S]*?)\s*}\s*,\s*\[\s*([^\]]*)\s*\]\s*\)`)
-	matches := effectRegex.FindAllStringSubmatch(code, -1)
-
-	for _, match := range matches {
-		if len(match) > 2 {
-			deps := []string{}
-			if match[2] != "" {
-				depsList := strings.Split(match[2], ",")
-				for _, dep := range depsList {
-					deps = append(deps, strings.TrimSpace(dep))
+// Propiedades que se leen del parámetro name en el cuerpo del componente, en
+// orden de aparición; whole indica que el objeto también se usa entero
+func (c *converter) propsAccesses(fn *function, name string) (members []string, whole bool) {
+	read := make(map[*ident]bool)
+	seen := make(map[string]bool)
+	visit := func(n node) bool {
+		switch n := n.(type) {
+		case *declarator:
+			// const { a, b } = props ya se ha convertido en props
+			return !c.consumed[n]
+		case *memberExpr:
+			if id, ok := n.object.(*ident); ok && id.name == name {
+				read[id] = true
+				if !seen[n.property] {
+					seen[n.property] = true
+					members = append(members, n.property)
 				}
 			}
+		case *ident:
+			if n.name == name && !read[n] {
+				whole = true
+			}
+		}
+		return true
+	}
+	if fn.body != nil {
+		walk(fn.body, visit)
+	} else {
+		walk(fn.expr, visit)
+	}
+	return members, whole
+}
 
-			effects = append(effects, EffectDefinition{
-				Dependencies: deps,
-				Body:         match[1],
-			})
+// Prop que lee una expresión: title o, si el parámetro de las props se
+// reescribe, props.title
+func (c *converter) propName(n node) (string, bool) {
+	switch e := unparen(n).(type) {
+	case *ident:
+		return e.name, true
+	case *memberExpr:
+		if id, ok := e.object.(*ident); ok && (id.name == c.propsParam || id.name == c.restProp) {
+			return e.property, true
 		}
 	}
+	return "", false
+}
 
-	return effects
+// Miembros del tipo de las props: el tipo anotado en el parámetro o, si no
+// hay anotación, la primera interface/type cuyo nombre termina en Props
+func (c *converter) propsTypeMembers(typ string) []*typeMember {
+	if typ != "" {
+		return c.typeMembers(typ, 0)
+	}
+	for _, stmt := range c.file.body {
+		switch d := unwrapExport(stmt).(type) {
+		case *interfaceDecl:
+			if strings.HasSuffix(d.name, "Props") {
+				return c.typeMembers(d.name, 0)
+			}
+		case *typeAlias:
+			if strings.HasSuffix(d.name, "Props") {
+				return c.typeMembers(d.name, 0)
+			}
+		}
+	}
+	return nil
 }
 
-func (t *Transpiler) extractFunctions(code string) []FunctionDefinition {
-	var functions []FunctionDefinition
-	componentName := t.extractComponentName(code)
+// Resolver los miembros de un tipo objeto declarado en el archivo
+func (c *converter) typeMembers(typ string, depth int) []*typeMember {
+	typ = strings.TrimSpace(typ)
+	if depth > 8 || typ == "" {
+		return nil
+	}
+	// A & B: miembros de ambos tipos
+	if parts := splitTopLevel(typ, '&'); len(parts) > 1 {
+		var members []*typeMember
+		for _, part := range parts {
+			members = append(members, c.typeMembers(part, depth+1)...)
+		}
+		return members
+	}
+	if strings.HasPrefix(typ, "{") {
+		members, _ := parseTypeLiteral(typ)
+		return members
+	}
 
-	// --- 1. Funciones flecha ---
-	arrowFuncRegex := regexp.MustCompile(
-		`const\s+(\w+)\s*=\s*(async\s*)?(\([^\)]*\)|\w+)\s*=>\s*{((?:[^{}]*|\{[^{}]*\})*)}`,
-	)
-	arrowMatches := arrowFuncRegex.FindAllStringSubmatch(code, -1)
+	name := typ
+	if i := strings.IndexByte(name, '<'); i != -1 {
+		name = name[:i]
+	}
+	for _, stmt := range c.file.body {
+		switch d := unwrapExport(stmt).(type) {
+		case *interfaceDecl:
+			if d.name != name {
+				continue
+			}
+			c.consumed[stmt] = true
+			var members []*typeMember
+			for _, base := range splitTopLevel(d.extends, ',') {
+				members = append(members, c.typeMembers(base, depth+1)...)
+			}
+			return append(members, d.members...)
+		case *typeAlias:
+			if d.name != name {
+				continue
+			}
+			c.consumed[stmt] = true
+			if d.members != nil {
+				return d.members
+			}
+			var members []*typeMember
+			for _, part := range splitTopLevel(d.typ, '&') {
+				members = append(members, c.typeMembers(part, depth+1)...)
+			}
+			return members
+		}
+	}
+	return nil
+}
 
-	for _, match := range arrowMatches {
-		if len(match) < 5 {
-			continue
+func unwrapExport(stmt node) node {
+	if exp, ok := stmt.(*exportDecl); ok && exp.decl != nil {
+		return exp.decl
+	}
+	return stmt
+}
+
+// Dividir un texto por un separador ignorando los que están anidados
+func splitTopLevel(text string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}', '>':
+			// La flecha de un tipo función no cierra nada: () => void
+			if text[i] == '>' && i > 0 && text[i-1] == '=' {
+				continue
+			}
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(text[start:i]))
+				start = i + 1
+			}
 		}
-		funcName := strings.TrimSpace(match[1])
-		isAsync := strings.TrimSpace(match[2]) == "async"
-		params := strings.TrimSpace(match[3])
-		body := strings.TrimSpace(match[4])
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+// Extraer states usando useState: const [count, setCount] = useState(0)
+func (c *converter) extractStates(body []node) []StateDefinition {
+	var states []StateDefinition
 
-		// Ignorar componente principal y funciones con hooks
-		if funcName == componentName || strings.Contains(body, "useState") || strings.Contains(body, "useEffect") {
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
 			continue
 		}
+		for _, d := range vd.decls {
+			hook, ok := unparen(d.init).(*hookCall)
+			pat, isPattern := d.target.(*arrayPattern)
+			if !ok || hook.hook != "useState" || !isPattern || len(pat.elems) == 0 || pat.elems[0] == nil {
+				continue
+			}
+			name, ok := pat.elems[0].target.(*ident)
+			if !ok {
+				continue
+			}
 
-		clean := t.cleanFunctionBody(body)
-		functions = append(functions, FunctionDefinition{
-			Name:   funcName,
-			Async:  isAsync,
-			Params: params,
-			Body:   clean,
-		})
+			c.consumed[d] = true
+			state := StateDefinition{Name: name.name, Type: "any", Order: c.order(d)}
+			if len(pat.elems) > 1 && pat.elems[1] != nil {
+				if setter, ok := pat.elems[1].target.(*ident); ok {
+					state.Setter = setter.name
+				}
+			}
+			if hook.typeArgs != "" {
+				state.Type = strings.TrimSuffix(strings.TrimPrefix(hook.typeArgs, "<"), ">")
+			}
+			if len(hook.args) > 0 {
+				state.InitialValue = c.stateInitializer(hook.args[0])
+			}
+			states = append(states, state)
+		}
 	}
 
-	// --- 2. Funciones clásicas (incluyendo async) ---
-	funcDeclRegex := regexp.MustCompile(`(async\s+)?function\s+(\w+)\s*(\([^\)]*\))\s*{((?:[^{}]*|\{[^{}]*\})*)}`)
-	funcMatches := funcDeclRegex.FindAllStringSubmatch(code, -1)
+	return states
+}
 
-	for _, match := range funcMatches {
-		if len(match) < 5 {
+// Variables que reciben el estado de un useReducer, incluidas las de una
+// desestructuración
+func reducerTargets(body []node) []string {
+	var names []string
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
 			continue
 		}
-		isAsync := strings.TrimSpace(match[1]) == "async"
-		funcName := strings.TrimSpace(match[2])
-		params := strings.TrimSpace(match[3])
-		body := strings.TrimSpace(match[4])
-
-		// Ignorar componente principal y funciones con hooks
-		if funcName == componentName || strings.Contains(body, "useState") || strings.Contains(body, "useEffect") {
-			continue
+		for _, d := range vd.decls {
+			hook, ok := unparen(d.init).(*hookCall)
+			pat, isPattern := d.target.(*arrayPattern)
+			if ok && hook.hook == "useReducer" && isPattern && len(pat.elems) > 0 && pat.elems[0] != nil {
+				names = append(names, boundNames(pat.elems[0].target)...)
+			}
 		}
+	}
+	return names
+}
 
-		clean := t.cleanFunctionBody(body)
-		functions = append(functions, FunctionDefinition{
-			Name:   funcName,
-			Async:  isAsync,
-			Params: params,
-			Body:   clean,
-		})
+// Registrar los setters de los estados para convertir sus llamadas
+func (c *converter) registerStates(states []StateDefinition) {
+	for _, state := range states {
+		if state.Setter != "" {
+			c.setters[state.Setter] = state.Name
+		}
+		c.stateTypes[state.Name] = state.Type
 	}
+	c.renameShadowedStates()
+}
 
-	return functions
+// Una variable local que oculta un estado en el que escribe un setter se
+// renombra para que la asignación llegue al estado:
+// (count: number) => setCount(count) -> (count2: number) => count = count2
+func (c *converter) renameShadowedStates() {
+	walk(c.file, func(n node) bool {
+		id, ok := n.(*ident)
+		if !ok || !c.outer(id) {
+			return true
+		}
+		state, ok := c.setters[id.name]
+		if !ok {
+			return true
+		}
+		if found := c.scopes.at[id].lookup(state); found != nil && !found.top {
+			key := scopedName{found, state}
+			if _, done := c.locals[key]; !done {
+				c.locals[key] = c.objectName(state)
+			}
+		}
+		return true
+	})
 }
 
-func (t *Transpiler) cleanFunctionBody(body string) string {
-	// Eliminar useState
-	body = regexp.MustCompile(`const\s*\[[^]]+\]\s*=\s*useState\([^)]*\);\s*`).ReplaceAllString(body, "")
+// Extraer reducers: const [state, dispatch] = useReducer(reducer, inicial, init?)
+func (c *converter) extractReducers(body []node) []ReducerDefinition {
+	var reducers []ReducerDefinition
 
-	// Detectar y convertir setters
-	setCalls := t.extractSetterCalls(body)
-	for _, call := range setCalls {
-		converted := t.convertSetterCall(call)
-		body = strings.Replace(body, call, converted, 1)
-	}
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			hook, ok := unparen(d.init).(*hookCall)
+			pat, isPattern := d.target.(*arrayPattern)
+			if !ok || hook.hook != "useReducer" || len(hook.args) < 2 || !isPattern || len(pat.elems) == 0 || pat.elems[0] == nil {
+				continue
+			}
+			c.consumed[d] = true
+			var name string
+			switch target := pat.elems[0].target.(type) {
+			case *ident:
+				name = target.name
+			case *objectPattern:
+				// const [{ count, step }, dispatch]: los campos se leen del
+				// estado para no perder la reactividad
+				name = c.objectName("state")
+				renames, ok := bindingAccesses(target, name)
+				if !ok {
+					name = ""
+					break
+				}
+				maps.Copy(c.renames, renames)
+			}
+			if name == "" {
+				c.warn(d, CodeUnsupportedHook, "el estado de useReducer solo se convierte si es una variable o una desestructuración de objeto sin valores por defecto ni resto")
+				c.todos[len(c.todos)-1].Source = strings.TrimSpace(nodeText(c.src, d))
+				continue
+			}
+
+			reducer := ReducerDefinition{
+				Order:        c.order(d),
+				Name:         name,
+				Reducer:      c.print(hook.args[0]),
+				Type:         "any",
+				ActionType:   "any",
+				InitialValue: c.print(hook.args[1]),
+			}
+			if len(pat.elems) > 1 && pat.elems[1] != nil {
+				if dispatch, ok := pat.elems[1].target.(*ident); ok {
+					reducer.Dispatch = dispatch.name
+				}
+			}
 
-	// Limpiar líneas vacías
-	lines := strings.Split(body, "\n")
-	var cleanLines []string
-	for _, line := range lines {
-		if trimmed := strings.TrimSpace(line); trimmed != "" {
-			cleanLines = append(cleanLines, line)
+			// Los tipos se toman de los parámetros de la función reductora
+			fn, isFn := unparen(hook.args[0]).(*function)
+			if id, ok := unparen(hook.args[0]).(*ident); ok {
+				fn, isFn = c.findFunction(id.name, body)
+			} else if isFn {
+				reducer.Reducer = "(" + reducer.Reducer + ")"
+			}
+			if isFn {
+				if len(fn.params) > 0 && fn.params[0].typ != "" {
+					reducer.Type = fn.params[0].typ
+				} else if fn.returnType != "" {
+					reducer.Type = fn.returnType
+				}
+				if len(fn.params) > 1 && fn.params[1].typ != "" {
+					reducer.ActionType = fn.params[1].typ
+				}
+			}
+
+			// Inicialización perezosa: useReducer(reducer, arg, init) -> init(arg)
+			if len(hook.args) > 2 {
+				init := c.print(hook.args[2])
+				if _, ok := unparen(hook.args[2]).(*function); ok {
+					init = "(" + init + ")"
+				}
+				reducer.InitialValue = init + "(" + reducer.InitialValue + ")"
+			}
+			reducers = append(reducers, reducer)
 		}
 	}
 
-	return strings.Join(cleanLines, "\n")
+	return reducers
 }
 
-func (t *Transpiler) convertSetterCall(call string) string {
-	setStateRegex := regexp.MustCompile(`^set([A-Z]\w*)\s*\(\s*((?s).*?)\s*\)$`)
-	submatches := setStateRegex.FindStringSubmatch(strings.TrimSpace(call))
-	if len(submatches) < 3 {
-		return call
+// Función declarada con ese nombre en el componente o en el módulo
+func (c *converter) findFunction(name string, body []node) (*function, bool) {
+	for _, stmts := range [][]node{body, c.file.body} {
+		for _, stmt := range stmts {
+			switch d := unwrapExport(stmt).(type) {
+			case *funcDecl:
+				if d.name == name {
+					return d.fn, true
+				}
+			case *varDecl:
+				for _, v := range d.decls {
+					if id, ok := v.target.(*ident); ok && id.name == name {
+						fn, ok := unparen(v.init).(*function)
+						return fn, ok
+					}
+				}
+			}
+		}
 	}
+	return nil, false
+}
 
-	setter := submatches[1]
-	rawValue := strings.TrimSpace(submatches[2])
-	stateVar := strings.ToLower(setter[:1]) + setter[1:]
+// Valor inicial de un estado; los inicializadores perezosos () => valor se desenvuelven
+func (c *converter) stateInitializer(arg node) string {
+	if fn, ok := unparen(arg).(*function); ok && fn.arrow && len(fn.params) == 0 && fn.expr != nil {
+		return c.print(unparen(fn.expr))
+	}
+	return c.print(arg)
+}
 
-	// Ver si el valor es una función flecha tipo (prev) => ({ ... })
-	if strings.HasPrefix(rawValue, "(") && strings.Contains(rawValue, "=>") {
-		// Separar en partes: (prev) => ({...})
-		parts := strings.SplitN(rawValue, "=>", 2)
-		body := strings.TrimSpace(parts[1])
+// Extraer refs usando useRef: const inputRef = useRef<HTMLInputElement>(null)
+func (c *converter) extractRefs(decl *componentDecl, body []node) []RefDefinition {
+	var refs []RefDefinition
+	elements := make(map[string]string) // ref -> etiqueta del elemento que la recibe
+	if decl != nil {
+		walk(decl.fn, func(n node) bool {
+			el, ok := n.(*jsxElement)
+			if !ok {
+				return true
+			}
+			for _, a := range el.attrs {
+				if attr, ok := a.(*jsxAttr); ok && attr.name == "ref" {
+					if id, ok := refTarget(attr); ok {
+						elements[id.name] = el.name
+					}
+				}
+			}
+			return true
+		})
+	}
 
-		// Eliminar paréntesis externos si los hay
-		if strings.HasPrefix(body, "(") && strings.HasSuffix(body, ")") {
-			body = strings.TrimPrefix(body, "(")
-			body = strings.TrimSuffix(body, ")")
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
 		}
+		for _, d := range vd.decls {
+			hook, ok := unparen(d.init).(*hookCall)
+			name, isIdent := d.target.(*ident)
+			if !ok || hook.hook != "useRef" || !isIdent {
+				continue
+			}
 
-		// Reemplazar "prev" o argumento por el estado real
-		body = strings.ReplaceAll(body, "prev", stateVar)
+			c.consumed[d] = true
+			ref := RefDefinition{Name: name.name, Order: c.order(d)}
+			if hook.typeArgs != "" {
+				ref.Type = strings.TrimSuffix(strings.TrimPrefix(hook.typeArgs, "<"), ">")
+			} else if d.typ != "" {
+				ref.Type = d.typ
+			}
+			if len(hook.args) > 0 {
+				ref.InitialValue = c.print(hook.args[0])
+			}
 
-		return fmt.Sprintf("%s = %s", stateVar, strings.TrimSpace(body))
+			tag, bound := elements[name.name]
+			ref.DOM = bound || isDOMType(ref.Type)
+			ref.Component = bound && !isHTMLName(tag)
+			if ref.DOM {
+				// El elemento se asigna con bind:this; null solo indica que aún no existe
+				ref.InitialValue = ""
+				ref.Type = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(ref.Type), "| null"))
+				if ref.Type == "" {
+					ref.Type = elementType(tag)
+				}
+			}
+			refs = append(refs, ref)
+		}
 	}
 
-	// Si no es función flecha, devolver como asignación directa
-	return fmt.Sprintf("%s = %s", stateVar, rawValue)
+	return refs
 }
 
-func (t *Transpiler) extractSetterCalls(code string) []string {
-	var results []string
+// Extraer valores derivados: los memorizados con useMemo y las constantes
+// del cuerpo del componente calculadas a partir de props, states u otros
+// derivados, que en React se recalculan en cada render
+func (c *converter) extractDerived(body []node, reactive map[string]bool) []DerivedDefinition {
+	var derived []DerivedDefinition
 
-	// Buscar todas las ocurrencias de "setX("
-	baseRegex := regexp.MustCompile(`set([A-Z]\w*)\s*\(`)
-	indexes := baseRegex.FindAllStringIndex(code, -1)
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
+		}
+		for _, d := range vd.decls {
+			if c.consumed[d] || d.init == nil {
+				continue
+			}
 
-	for _, idx := range indexes {
-		start := idx[0]
-		i := idx[1] // posición después del paréntesis de apertura
+			var def DerivedDefinition
+			if hook, ok := unparen(d.init).(*hookCall); ok {
+				if hook.hook != "useMemo" || len(hook.args) == 0 {
+					continue
+				}
+				def = c.memoDefinition(d, hook)
+			} else {
+				// const fullName = first + " " + last
+				init := unparen(d.init)
+				if _, isFn := init.(*function); isFn || vd.kind != "const" || containsJSX(init) || firstHook(init) != nil {
+					continue
+				}
+				deps := references(init, reactive)
+				if len(deps) == 0 {
+					continue
+				}
+				def = DerivedDefinition{Name: c.print(d.target), Type: d.typ, Expression: c.print(d.init), Dependencies: deps}
+			}
+			def.Order = c.order(d)
 
-		openParens := 1
-		end := i
+			c.consumed[d] = true
+			derived = append(derived, def)
+			for _, name := range boundNames(d.target) {
+				reactive[name] = true
+			}
+		}
+	}
 
-		for end < len(code) && openParens > 0 {
-			c := code[end]
-			switch c {
-			case '(':
-				openParens++
-			case ')':
-				openParens--
+	return derived
+}
+
+// const total = useMemo(() => ..., [items])
+func (c *converter) memoDefinition(d *declarator, hook *hookCall) DerivedDefinition {
+	def := DerivedDefinition{Name: c.print(d.target), Type: d.typ}
+	if hook.typeArgs != "" {
+		def.Type = strings.TrimSuffix(strings.TrimPrefix(hook.typeArgs, "<"), ">")
+	}
+	if fn, ok := unparen(hook.args[0]).(*function); ok && len(fn.params) == 0 {
+		if fn.body != nil {
+			def.Body = c.functionBody(fn)
+		} else {
+			def.Expression = c.print(unparen(fn.expr))
+		}
+	} else {
+		// useMemo(calcular, deps): la función se invoca dentro del derivado
+		def.Expression = c.print(hook.args[0]) + "()"
+	}
+	if len(hook.args) > 1 {
+		def.Dependencies = c.dependencies(hook.args[1])
+	}
+	return def
+}
+
+// Nombres de names referenciados en n, en orden de aparición y sin repetir.
+// Las claves de los objetos no cuentan como referencias.
+func references(n node, names map[string]bool) []string {
+	var found []string
+	seen := make(map[string]bool)
+	var visit func(n node) bool
+	visit = func(n node) bool {
+		switch n := n.(type) {
+		case *ident:
+			if names[n.name] && !seen[n.name] {
+				seen[n.name] = true
+				found = append(found, n.name)
+			}
+		case *property:
+			if !n.computed && !n.shorthand && !n.spread {
+				walk(n.value, visit)
+				return false
 			}
-			end++
 		}
+		return true
+	}
+	walk(n, visit)
+	return found
+}
 
-		if openParens == 0 {
-			call := code[start:end]
-			results = append(results, call)
+// Identificadores que declara un destino de asignación o un patrón
+func boundNames(target node) []string {
+	var names []string
+	switch t := target.(type) {
+	case *ident:
+		names = append(names, t.name)
+	case *objectPattern:
+		for _, p := range t.props {
+			names = append(names, boundNames(p.value)...)
+		}
+	case *arrayPattern:
+		for _, e := range t.elems {
+			if e != nil {
+				names = append(names, boundNames(e.target)...)
+			}
 		}
 	}
+	return names
+}
 
-	return results
+// Variable que recibe el atributo ref={variable}
+func refTarget(attr *jsxAttr) (*ident, bool) {
+	container, ok := attr.value.(*jsxExprContainer)
+	if !ok {
+		return nil, false
+	}
+	id, ok := unparen(container.expr).(*ident)
+	return id, ok
 }
 
-// Extraer nombre del componente
-func (t *Transpiler) extractComponentName(code string) string {
-	// Buscar export default function ComponentName
-	nameRegex := regexp.MustCompile(`(?:export\s+default\s+)?function\s+(\w+)`)
-	matches := nameRegex.FindStringSubmatch(code)
+// Tipos de TypeScript que representan elementos del DOM
+func isDOMType(typ string) bool {
+	typ = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(typ), "| null"))
+	if typ == "Element" {
+		return true
+	}
+	return (strings.HasPrefix(typ, "HTML") || strings.HasPrefix(typ, "SVG")) && strings.HasSuffix(typ, "Element")
+}
+
+// Interfaces del DOM de las etiquetas más habituales
+var elementTypes = map[string]string{
+	"a": "HTMLAnchorElement", "audio": "HTMLAudioElement", "button": "HTMLButtonElement",
+	"canvas": "HTMLCanvasElement", "dialog": "HTMLDialogElement", "div": "HTMLDivElement",
+	"form": "HTMLFormElement", "img": "HTMLImageElement", "input": "HTMLInputElement",
+	"li": "HTMLLIElement", "ol": "HTMLOListElement", "p": "HTMLParagraphElement",
+	"select": "HTMLSelectElement", "span": "HTMLSpanElement", "table": "HTMLTableElement",
+	"textarea": "HTMLTextAreaElement", "ul": "HTMLUListElement", "video": "HTMLVideoElement",
+	"svg": "SVGSVGElement",
+}
 
-	if len(matches) > 1 {
-		return matches[1]
+func elementType(tag string) string {
+	if typ, ok := elementTypes[tag]; ok {
+		return typ
 	}
+	return "HTMLElement"
+}
+
+// Hooks de efecto y el tipo de efecto de Svelte al que equivalen
+var effectHooks = map[string]EffectKind{
+	"useEffect":          EffectKindEffect,
+	"useLayoutEffect":    EffectKindPre,
+	"useInsertionEffect": EffectKindPre,
+}
+
+// Extraer useEffect hooks: useEffect(() => { ... }, [dependencies]). Sin
+// lista de dependencias React ejecuta el efecto en cada render; en Svelte
+// basta con $effect, que se repite cuando cambia cualquier valor que lee.
+func (c *converter) extractEffects(body []node, reactive map[string]bool) []EffectDefinition {
+	var effects []EffectDefinition
+	localFns := localFunctions(body)
+
+	for _, stmt := range body {
+		es, ok := stmt.(*exprStmt)
+		if !ok {
+			continue
+		}
+		hook, ok := es.expr.(*hookCall)
+		if !ok || len(hook.args) == 0 {
+			continue
+		}
+		kind, isEffect := effectHooks[hook.hook]
+		fn, isFn := unparen(hook.args[0]).(*function)
+		if !isEffect || !isFn {
+			continue
+		}
 
-	// Buscar const ComponentName = () =>
-	arrowRegex := regexp.MustCompile(`(?:export\s+)?const\s+(\w+)\s*=\s*\([^)]*\)\s*=>`)
-	arrowMatches := arrowRegex.FindStringSubmatch(code)
+		var deps *arrayLit
+		if len(hook.args) > 1 {
+			if deps, ok = unparen(hook.args[1]).(*arrayLit); !ok {
+				continue
+			}
+		}
+		c.consumed[stmt] = true
 
-	if len(arrowMatches) > 1 {
-		return arrowMatches[1]
+		effect := EffectDefinition{Kind: kind, Body: c.functionBody(fn), Cleanup: hasCleanup(fn), Order: c.order(stmt)}
+		if deps != nil {
+			effect.Dependencies = c.dependencies(deps)
+			effect.Untrack = c.needsUntrack(fn, deps, reactive, localFns)
+		}
+		effects = append(effects, effect)
 	}
 
-	return "Component"
+	return effects
 }
 
-// Procesar JSX y convertirlo a sintaxis de Svelte
-func (t *Transpiler) processJSX(jsx string) (string, error) {
-	if jsx == "" {
-		return "", nil
+// Svelte registra como dependencia todo lo que el efecto lee, mientras que
+// React solo usa la lista de dependencias. El cuerpo se ejecuta con untrack
+// si lee valores reactivos que no están en la lista, si no lee alguno de los
+// que sí están o si llama a funciones del componente, cuyas lecturas Svelte
+// también registraría.
+func (c *converter) needsUntrack(fn *function, deps *arrayLit, reactive, localFns map[string]bool) bool {
+	listed := make(map[string]bool)
+	for _, dep := range deps.elems {
+		if root := rootIdent(dep); root != nil {
+			listed[root.name] = true
+		}
 	}
 
-	// Convertir sintaxis de JSX a Svelte
-	processed := t.replaceComments(jsx)
+	read := make(map[string]bool)
+	calls := false
+	walk(fn, func(n node) bool {
+		switch n := n.(type) {
+		case *ident:
+			if reactive[n.name] {
+				read[n.name] = true
+			}
+		case *callExpr:
+			id, ok := n.callee.(*ident)
+			if !ok {
+				break
+			}
+			if localFns[id.name] {
+				calls = true
+			}
+			// setCount(c => c + 1) se convierte en count = count + 1
+			if state, isSetter := c.setters[id.name]; isSetter && len(n.args) == 1 {
+				if _, isFn := unparen(n.args[0]).(*function); isFn {
+					read[state] = true
+				}
+			}
+		}
+		return true
+	})
 
-	// Convertir className a class
-	processed = regexp.MustCompile(`className=`).ReplaceAllString(processed, `class=`)
+	if calls {
+		return true
+	}
+	for name := range read {
+		if !listed[name] {
+			return true
+		}
+	}
+	for name := range listed {
+		if reactive[name] && !read[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// Funciones declaradas en el cuerpo del componente
+func localFunctions(body []node) map[string]bool {
+	fns := make(map[string]bool)
+	for _, stmt := range body {
+		switch d := stmt.(type) {
+		case *funcDecl:
+			fns[d.name] = true
+		case *varDecl:
+			for _, v := range d.decls {
+				id, ok := v.target.(*ident)
+				if !ok {
+					continue
+				}
+				if _, isFn := unparen(v.init).(*function); isFn {
+					fns[id.name] = true
+				}
+				if hook, ok := unparen(v.init).(*hookCall); ok && hook.hook == "useCallback" {
+					fns[id.name] = true
+				}
+			}
+		}
+	}
+	return fns
+}
 
-	processed = t.deleteFragments(processed)
+// Indica si el efecto devuelve una función de limpieza
+func hasCleanup(fn *function) bool {
+	if fn.body == nil {
+		return false
+	}
+	found := false
+	walk(fn.body, func(n node) bool {
+		switch n := n.(type) {
+		case *function:
+			return false
+		case *returnStmt:
+			if n.arg != nil {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
 
-	processed = t.replaceEvents(processed)
+// Lista de dependencias de un hook: [a, b.c]
+func (c *converter) dependencies(arg node) []string {
+	deps := []string{}
+	if array, ok := unparen(arg).(*arrayLit); ok {
+		for _, dep := range array.elems {
+			// Los setters son estables y no tienen equivalente que leer
+			if id, ok := dep.(*ident); ok && c.setters[id.name] != "" {
+				continue
+			}
+			if dep != nil {
+				deps = append(deps, c.print(dep))
+			}
+		}
+	}
+	return deps
+}
 
-	processed = t.replaceLoops(processed)
+// Funciones auxiliares del módulo y funciones declaradas dentro del componente
+func (c *converter) extractFunctions(decl *componentDecl, body []node) ([]FunctionDefinition, []FunctionDefinition) {
+	locals := c.plainLocals(body)
 
-	processed = t.replaceConditionals(processed)
+	// --- 1. Funciones auxiliares del módulo ---
+	var module []FunctionDefinition
+	if !c.isSibling() {
+		module = c.collectFunctions(decl, c.file.body, locals)
+	}
 
-	return processed, nil
+	// --- 2. Funciones declaradas dentro del componente ---
+	return module, c.collectFunctions(decl, body, locals)
 }
 
-func (t *Transpiler) replaceLoops(jsx string) string {
-	mapRegex := regexp.MustCompile(`\{\s*([a-zA-Z0-9_.$]+)\.map\s*\(\s*\(?\s*([a-zA-Z0-9_]+)\s*(?:,\s*([a-zA-Z0-9_]+))?\s*\)?\s*=>\s*\(\s*([\s\S]+?)\s*\)\s*\)\s*\}`)
+// Funciones declaradas en una lista de sentencias
+func (c *converter) collectFunctions(decl *componentDecl, stmts []node, locals map[string]bool) []FunctionDefinition {
+	var functions []FunctionDefinition
+	for _, stmt := range stmts {
+		switch d := unwrapExport(stmt).(type) {
+		case *funcDecl:
+			// Ignorar el componente principal y los hooks ya convertidos
+			if (decl != nil && d.fn == decl.fn) || d.fn.body == nil || c.consumed[stmt] {
+				continue
+			}
+			c.consumed[stmt] = true
+			def := c.functionDefinition(d.name, d.fn)
+			def.Order = c.order(stmt)
+			_, def.Exported = stmt.(*exportDecl)
+			functions = append(functions, def)
+		case *varDecl:
+			for _, v := range d.decls {
+				id, ok := v.target.(*ident)
+				if !ok || c.consumed[v] {
+					continue
+				}
+				fn, isFn := unparen(v.init).(*function)
+
+				// const handler = useCallback((e) => { ... }, [deps])
+				if hook, ok := unparen(v.init).(*hookCall); ok && hook.hook == "useCallback" && len(hook.args) > 0 {
+					fn, isFn = unparen(hook.args[0]).(*function)
+					if isFn && len(hook.args) > 1 {
+						c.checkCallbackDeps(id.name, hook.args[1], locals)
+					}
+				}
 
-	return mapRegex.ReplaceAllStringFunc(jsx, func(match string) string {
-		m := mapRegex.FindStringSubmatch(match)
-		if len(m) < 5 {
-			return match
+				if !isFn || (decl != nil && fn == decl.fn) {
+					continue
+				}
+				c.consumed[v] = true
+				def := c.functionDefinition(id.name, fn)
+				def.Order = c.order(v)
+				functions = append(functions, def)
+			}
 		}
+	}
+	return functions
+}
 
-		collection := strings.TrimSpace(m[1]) // ej: packages
-		item := strings.TrimSpace(m[2])       // ej: pkg
-		index := strings.TrimSpace(m[3])      // ej: i (opcional)
-		body := strings.TrimSpace(m[4])       // JSX
-		key := ""
+// Orden de emisión de una declaración: su posición en el archivo, con las
+// del módulo antes que las del componente aunque aparezcan después de él
+func (c *converter) order(n node) int {
+	start, _ := n.bounds()
+	if c.decl != nil && !within(n, c.decl.fn) {
+		return start - len(c.src)
+	}
+	return start
+}
 
-		// Buscar key
-		keyRegex := regexp.MustCompile(`key\s*=\s*\{([^}]+)\}`)
-		if keyMatch := keyRegex.FindStringSubmatch(body); len(keyMatch) > 1 {
-			key = strings.TrimSpace(keyMatch[1])
-			body = keyRegex.ReplaceAllString(body, "") // eliminar key del JSX
+// Variables del componente que no se han convertido en props, states, refs
+// ni derivados y que no son funciones: en Svelte no son reactivas
+func (c *converter) plainLocals(body []node) map[string]bool {
+	locals := make(map[string]bool)
+	for _, stmt := range body {
+		vd, ok := stmt.(*varDecl)
+		if !ok {
+			continue
 		}
+		for _, d := range vd.decls {
+			if c.consumed[d] {
+				continue
+			}
+			if _, isFn := unparen(d.init).(*function); isFn {
+				continue
+			}
+			if hook, ok := unparen(d.init).(*hookCall); ok && hook.hook == "useCallback" {
+				continue
+			}
+			for _, name := range boundNames(d.target) {
+				locals[name] = true
+			}
+		}
+	}
+	return locals
+}
 
-		indexPart := ""
-		if index != "" {
-			indexPart = ", " + index
+// Avisar de las dependencias de useCallback que no serán reactivas en Svelte
+func (c *converter) checkCallbackDeps(name string, deps node, locals map[string]bool) {
+	array, ok := unparen(deps).(*arrayLit)
+	if !ok {
+		return
+	}
+	for _, dep := range array.elems {
+		if root := rootIdent(dep); root != nil && locals[root.name] {
+			c.warn(dep, CodeCallbackDependency, "%s depende de %s, que no es reactivo en Svelte; conviértalo en $derived", name, root.name)
 		}
+	}
+}
 
-		keyPart := ""
-		if key != "" {
-			keyPart = fmt.Sprintf(" (%s)", key)
+// Identificador base de una expresión como a.b[c].d
+func rootIdent(n node) *ident {
+	for {
+		switch e := unparen(n).(type) {
+		case *ident:
+			return e
+		case *memberExpr:
+			n = e.object
+		case *indexExpr:
+			n = e.object
+		case *nonNullExpr:
+			n = e.expr
+		default:
+			return nil
 		}
+	}
+}
 
-		return fmt.Sprintf("\n{#each %s as %s%s%s}\n%s\n{/each}\n", collection, item, indexPart, keyPart, body)
-	})
+func (c *converter) functionDefinition(name string, fn *function) FunctionDefinition {
+	if containsJSX(fn) {
+		c.warn(fn, CodeJSXInScript, "la función %s contiene JSX, que no es válido en el script de Svelte", name)
+	}
+	return FunctionDefinition{
+		Name:       name,
+		Async:      fn.async,
+		Params:     c.functionParams(fn),
+		ReturnType: fn.returnType,
+		Body:       c.functionBody(fn),
+	}
 }
-func (t *Transpiler) replaceConditionals(jsx string) string {
-	// 1. Ternario
-	ternaryRegex := regexp.MustCompile(`\{\s*([^{}?]+?)\s*\?\s*\(([\s\S]+?)\)\s*:\s*\(([\s\S]+?)\)\s*\}`)
-	jsx = ternaryRegex.ReplaceAllString(jsx, "\n{#if $1}\n$2\n{:else}\n$3\n{/if}\n")
-
-	// 2. && con paréntesis multilínea (ya balanceado)
-	andBlockRegex := regexp.MustCompile(`\{\s*([^{}]+?)\s*&&\s*\(\s*([\s\S]+?)\s*\)\s*\}`)
-	jsx = andBlockRegex.ReplaceAllStringFunc(jsx, func(match string) string {
-		m := andBlockRegex.FindStringSubmatch(match)
-		if len(m) < 3 {
-			return match
-		}
-		condition := strings.TrimSpace(m[1])
-		content := strings.TrimSpace(m[2])
-		return fmt.Sprintf("\n{#if %s}\n%s\n{/if}\n", condition, content)
-	})
 
-	// 3. && inline sin paréntesis (auto-cerrado o <Tag>...</Tag> en una línea)
-	jsx = processInlineConditionals(jsx)
+// Lista de parámetros entre paréntesis, con sus tipos y valores por defecto
+func (c *converter) functionParams(fn *function) string {
+	params := make([]string, len(fn.params))
+	for i, prm := range fn.params {
+		params[i] = c.print(prm)
+	}
+	return fn.typeParams + "(" + strings.Join(params, ", ") + ")"
+}
 
-	return jsx
+// Cuerpo de una función como lista de sentencias
+func (c *converter) functionBody(fn *function) string {
+	if fn.body != nil {
+		return blockBody(c.print(fn.body))
+	}
+	expr := unparen(fn.expr)
+	text := c.code.printNode(expr, fn)
+	if c.isStatementExpr(expr) {
+		return text + ";"
+	}
+	return "return " + text + ";"
 }
 
-func processInlineConditionals(code string) string {
-	pattern := regexp.MustCompile(`\{\s*([a-zA-Z0-9_.!?]+)\s*&&\s*(<[^}]+>)\s*\}`)
-	return pattern.ReplaceAllStringFunc(code, func(match string) string {
-		sub := pattern.FindStringSubmatch(match)
-		if len(sub) < 3 {
-			return match
+// Expresiones que en el cuerpo de una arrow function se usan como sentencia
+func (c *converter) isStatementExpr(expr node) bool {
+	switch e := expr.(type) {
+	case *assignExpr, *updateExpr:
+		return true
+	case *callExpr:
+		if id, ok := e.callee.(*ident); ok {
+			_, isSetter := c.setters[id.name]
+			return isSetter
 		}
-		condition := strings.TrimSpace(sub[1])
-		content := strings.TrimSpace(sub[2])
+	}
+	return false
+}
 
-		return fmt.Sprintf("\n{#if %s}\n%s\n{/if}\n", condition, content)
-	})
+// Reescrituras aplicadas a todo el código que se copia al componente Svelte
+func (c *converter) replace(n, parent node) (string, bool) {
+	if c.skip[n] {
+		return "", true
+	}
+
+	switch n := n.(type) {
+	case *callExpr:
+		if text, ok := c.convertSetterCall(n, parent); ok {
+			return text, true
+		}
+	case *hookCall:
+		if n.hook == "useContext" {
+			return c.getContextCall(n), true
+		}
+	case *ident:
+		if name, ok := c.locals[c.binding(n)]; ok {
+			return name, true
+		}
+		if !c.outer(n) {
+			break
+		}
+		if name, ok := c.renames[n.name]; ok {
+			return name, true
+		}
+		// Un setter pasado como valor: onChange={setName}
+		if state, ok := c.setters[n.name]; ok {
+			if call, isCall := parent.(*callExpr); !isCall || call.callee != node(n) {
+				return c.setterFunction(state), true
+			}
+		}
+	case *memberExpr:
+		// inputRef.current -> inputRef
+		if id, ok := n.object.(*ident); ok && c.refs[id.name] && n.property == "current" && !n.optional && c.outer(id) {
+			return id.name, true
+		}
+		// props.title -> title
+		if id, ok := n.object.(*ident); ok && c.propsParam != "" && id.name == c.propsParam && c.outer(id) {
+			return n.property, true
+		}
+	case *property:
+		if (len(c.renames) == 0 && len(c.setters) == 0 && len(c.locals) == 0) || n.spread || n.computed {
+			break
+		}
+		// La clave de una propiedad no es una referencia y no se renombra
+		if n.shorthand {
+			if id, ok := n.value.(*ident); ok && n.def == nil {
+				if name, ok := c.locals[c.binding(id)]; ok {
+					return id.name + ": " + name, true
+				}
+				if !c.outer(id) {
+					break
+				}
+				if name, ok := c.renames[id.name]; ok {
+					return id.name + ": " + name, true
+				}
+				if state, ok := c.setters[id.name]; ok {
+					return id.name + ": " + c.setterFunction(state), true
+				}
+			}
+			break
+		}
+		vs, _ := n.value.bounds()
+		return c.src[n.start:vs] + c.code.printNode(n.value, n), true
+	}
+
+	return c.replaceMarkup(n, parent)
 }
 
-func (t *Transpiler) replaceEvents(jsx string) string {
-	// Convertir onClick a onclick (y otros eventos)
-	eventMap := map[string]string{
-		"onClick":     "onclick",
-		"onChange":    "onchange",
-		"onSubmit":    "onsubmit",
-		"onFocus":     "onfocus",
-		"onBlur":      "onblur",
-		"onKeyDown":   "onkeydown",
-		"onKeyUp":     "onkeyup",
-		"onMouseOver": "onmouseover",
-		"onMouseOut":  "onmouseout",
+// Convertir setX(valor) en una asignación al estado
+func (c *converter) convertSetterCall(call *callExpr, parent node) (string, bool) {
+	id, ok := call.callee.(*ident)
+	if !ok {
+		return "", false
+	}
+	state, ok := c.setters[id.name]
+	if !ok || len(call.args) > 1 || !c.outer(id) {
+		return "", false
 	}
 
-	processed := jsx
+	value := "undefined"
+	if len(call.args) == 1 {
+		value = c.setterValue(state, call.args[0])
+	}
+	assignment := state + " = " + value
+
+	switch p := parent.(type) {
+	case *exprStmt:
+		return assignment, true
+	case *function:
+		if p.expr == node(call) {
+			return assignment, true
+		}
+	}
+	return "(" + assignment + ")", true
+}
+
+// Función equivalente a un setter que no se llama directamente
+func (c *converter) setterFunction(state string) string {
+	param := "value"
+	if state == param {
+		param = "next"
+	}
+	return fmt.Sprintf("(%s: %s) => (%s = %s)", param, c.stateTypes[state], state, param)
+}
 
-	for reactEvent, svelteEvent := range eventMap {
-		processed = regexp.MustCompile(reactEvent+`=`).ReplaceAllString(processed, svelteEvent+`=`)
+// Valor asignado por un setter; en la forma funcional (prev) => ... se
+// sustituye el parámetro por la variable de estado
+func (c *converter) setterValue(state string, arg node) string {
+	fn, ok := unparen(arg).(*function)
+	if !ok || len(fn.params) != 1 {
+		return c.print(arg)
 	}
+	prev, ok := fn.params[0].target.(*ident)
+	if !ok || fn.body != nil {
+		c.warn(arg, CodeSetterUpdater, "la función de actualización de %s se ejecuta de forma inmediata; revise el resultado", state)
+		return "(" + c.print(arg) + ")(" + state + ")"
+	}
+
+	key := c.binding(prev)
+	c.locals[key] = state
+	defer delete(c.locals, key)
+	return c.print(unparen(fn.expr))
+}
 
-	return processed
+// Variable declarada en un ámbito concreto
+type scopedName struct {
+	scope *scope
+	name  string
 }
 
-func (t *Transpiler) deleteFragments(jsx string) string {
-	// Convertir fragmentos <React.Fragment> o <> a elementos div (simplificación)
-	processed := regexp.MustCompile(`<React\.Fragment[^>]*>`).ReplaceAllString(jsx, `<div>`)
-	processed = regexp.MustCompile(`</React\.Fragment>`).ReplaceAllString(processed, `</div>`)
-	processed = regexp.MustCompile(`<>`).ReplaceAllString(processed, `<div>`)
-	processed = regexp.MustCompile(`</>`).ReplaceAllString(processed, `</div>`)
+// Declaración a la que se refiere un identificador
+func (c *converter) binding(id *ident) scopedName {
+	return scopedName{c.scopes.at[id].lookup(id.name), id.name}
+}
+
+// Si el identificador es una referencia a una variable del componente, de un
+// hook o del módulo, y no una declaración ni una variable local de una
+// función anidada o de un bloque que oculta a la del componente
+func (c *converter) outer(id *ident) bool {
+	if c.scopes.declared[id] {
+		return false
+	}
+	sc, ok := c.scopes.at[id]
+	if !ok {
+		return true
+	}
+	found := sc.lookup(id.name)
+	return found == nil || found.top
+}
+
+// Avisar de las sentencias del módulo y del componente que no se consumieron
+func (c *converter) reportUnconverted(decl *componentDecl, body []node) {
+	// Las sentencias del módulo solo se revisan al convertir el componente principal
+	var module []node
+	if c.main == nil || c.main == decl {
+		module = c.file.body
+	}
+	for _, stmt := range module {
+		if decl != nil && stmt == decl.stmt {
+			continue
+		}
+		switch s := stmt.(type) {
+		case *emptyStmt:
+			continue
+		case *exprStmt:
+			// Componente.displayName = '...' no tiene equivalente en Svelte
+			if isDisplayName(s) {
+				continue
+			}
+		case *exportDecl:
+			// export default Componente; export default memo(Componente); o
+			// export { Componente };
+			if id, ok := exportedComponent(s); ok && decl != nil && id == decl.name {
+				continue
+			}
+			if s.decl == nil && s.expr == nil && decl != nil && len(s.names) == 1 && s.names[0] == decl.name {
+				continue
+			}
+		}
+		c.reportStatement(stmt, "sentencia de nivel de módulo no convertida")
+	}
 
-	return processed
+	for _, stmt := range body {
+		if _, ok := stmt.(*emptyStmt); ok {
+			continue
+		}
+		c.reportStatement(stmt, "sentencia del componente no convertida")
+	}
 }
 
-func (t *Transpiler) replaceComments(jsx string) string {
-	// 1. Convertir comentarios JSX a comentarios HTML
-	jsxCommentRegex := regexp.MustCompile(`\{\s*/\*\s*(.*?)\s*\*/\s*\}`)
-	jsx = jsxCommentRegex.ReplaceAllString(jsx, `<!-- $1 -->`)
+func (c *converter) reportStatement(stmt node, message string) {
+	if c.consumed[stmt] {
+		return
+	}
 
-	// 2. Eliminar comentarios de línea JS (// ...) que no están en JSX
-	singleLineCommentRegex := regexp.MustCompile(`(?m)^\s*//.*$`)
-	jsx = singleLineCommentRegex.ReplaceAllString(jsx, ``)
+	// En las declaraciones de variables se informa de cada declarador pendiente
+	if vd, ok := unwrapExport(stmt).(*varDecl); ok {
+		var pending []node
+		for _, d := range vd.decls {
+			if !c.consumed[d] {
+				pending = append(pending, d)
+			}
+		}
+		if len(pending) < len(vd.decls) {
+			for _, d := range pending {
+				c.drop(d, message)
+			}
+			return
+		}
+	}
+	c.drop(stmt, message)
+}
+
+// Registrar una construcción omitida: aviso y marcador TODO con el código original
+func (c *converter) drop(n node, message string) {
+	code := CodeUnconvertedStatement
+	if hook := firstHook(n); hook != nil {
+		code = CodeUnsupportedHook
+		message = fmt.Sprintf("el hook %s no tiene conversión a Svelte y se omitió", hook.hook)
+	}
+	c.warn(n, code, "%s", message)
+	c.todos[len(c.todos)-1].Source = strings.TrimSpace(nodeText(c.src, n))
+}
 
-	// 3. Eliminar comentarios de bloque JS (/* ... */)
-	blockCommentRegex := regexp.MustCompile(`(?s)/\*.*?\*/`)
-	jsx = blockCommentRegex.ReplaceAllString(jsx, ``)
+// Primer hook llamado dentro de un nodo
+func firstHook(n node) *hookCall {
+	var found *hookCall
+	walk(n, func(n node) bool {
+		if hook, ok := n.(*hookCall); ok && found == nil {
+			found = hook
+		}
+		return found == nil
+	})
+	return found
+}
 
-	return jsx
+// Indica si el nodo contiene JSX
+func containsJSX(n node) bool {
+	found := false
+	walk(n, func(n node) bool {
+		if isJSX(n) {
+			found = true
+		}
+		return !found
+	})
+	return found
 }
@@ -0,0 +1,122 @@
+package transpiler
+
+import (
+	"strings"
+)
+
+// Reescritor de código: reimprime un nodo a partir del texto original,
+// sustituyendo los subnodos para los que replace devuelve un texto nuevo.
+// Todo lo que no se reemplaza (espacios, comentarios, tipos) se conserva.
+type rewriter struct {
+	src     string
+	replace func(n, parent node) (string, bool)
+}
+
+func (r *rewriter) print(n node) string {
+	return r.printNode(n, nil)
+}
+
+func (r *rewriter) printNode(n, parent node) string {
+	if r.replace != nil {
+		if text, ok := r.replace(n, parent); ok {
+			return text
+		}
+	}
+	return r.printChildren(n)
+}
+
+// Imprimir el nodo sin aplicar replace sobre él, solo sobre sus hijos
+func (r *rewriter) printChildren(n node) string {
+	start, end := n.bounds()
+	var b strings.Builder
+	cur := start
+	for _, child := range children(n) {
+		cs, ce := child.bounds()
+		if cs < cur {
+			continue
+		}
+		b.WriteString(r.src[cur:cs])
+		b.WriteString(r.printNode(child, n))
+		cur = ce
+	}
+	if cur < end {
+		b.WriteString(r.src[cur:end])
+	}
+	return b.String()
+}
+
+// Texto original de un nodo
+func nodeText(src string, n node) string {
+	start, end := n.bounds()
+	return src[start:end]
+}
+
+// Columna (en bytes) de una posición dentro de su línea
+func columnOf(src string, pos int) int {
+	return pos - (strings.LastIndexByte(src[:pos], '\n') + 1)
+}
+
+// Espacios iniciales de la línea que contiene pos
+func lineIndent(src string, pos int) string {
+	lineStart := strings.LastIndexByte(src[:pos], '\n') + 1
+	end := lineStart
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t') {
+		end++
+	}
+	return src[lineStart:end]
+}
+
+// Mover un bloque de texto cuya primera línea estaba en la columna from para
+// que empiece en la columna to, desplazando también el resto de líneas
+func reindent(text string, from, to int) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = shiftLine(lines[i], to-from)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func shiftLine(line string, delta int) string {
+	if strings.TrimSpace(line) == "" {
+		return ""
+	}
+	if delta >= 0 {
+		return strings.Repeat(" ", delta) + line
+	}
+	trim := 0
+	for trim < -delta && trim < len(line) && (line[trim] == ' ' || line[trim] == '\t') {
+		trim++
+	}
+	return line[trim:]
+}
+
+// Quitar la indentación común de todas las líneas excepto la primera
+func dedent(text string) string {
+	lines := strings.Split(text, "\n")
+	common := -1
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common == -1 || indent < common {
+			common = indent
+		}
+	}
+	if common <= 0 {
+		return text
+	}
+	for i := 1; i < len(lines); i++ {
+		lines[i] = shiftLine(lines[i], -common)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Texto interior de un bloque { ... } sin las llaves ni la indentación común
+func blockBody(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "{")
+	text = strings.TrimSuffix(text, "}")
+	text = strings.Trim(text, "\n")
+	return strings.TrimSpace(dedent("\n" + text))
+}
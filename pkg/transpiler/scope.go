@@ -0,0 +1,182 @@
+package transpiler
+
+// Ámbito léxico: los nombres declarados en el módulo, una función o un bloque
+type scope struct {
+	parent *scope
+	names  map[string]bool
+	top    bool // el módulo o una función declarada en él (un componente o un hook)
+}
+
+func newScope(parent *scope, top bool) *scope {
+	return &scope{parent: parent, names: make(map[string]bool), top: top}
+}
+
+// Ámbito que declara el nombre visible desde s, o nil si es global
+func (s *scope) lookup(name string) *scope {
+	for ; s != nil; s = s.parent {
+		if s.names[name] {
+			return s
+		}
+	}
+	return nil
+}
+
+// Ámbitos de los identificadores de un archivo. Las variables que se
+// renombran o se convierten (states, resultados de hooks, contextos) se
+// declaran en el componente o el hook; una función o un bloque anidados que
+// declaran el mismo nombre lo ocultan.
+type scopes struct {
+	at       map[*ident]*scope // ámbito en el que aparece cada identificador
+	declared map[*ident]bool   // identificadores que no son referencias: declaraciones y claves
+}
+
+func analyzeScopes(prog *program) *scopes {
+	s := &scopes{at: make(map[*ident]*scope), declared: make(map[*ident]bool)}
+	module := newScope(nil, true)
+	s.declare(module, prog.body)
+	for _, stmt := range prog.body {
+		s.visit(stmt, module, 0)
+	}
+	return s
+}
+
+// Registrar los nombres que declaran las sentencias de un bloque, antes de
+// recorrerlo, para que las referencias anteriores a la declaración también
+// los vean
+func (s *scopes) declare(sc *scope, stmts []node) {
+	for _, stmt := range stmts {
+		switch d := unwrapExport(stmt).(type) {
+		case *varDecl:
+			for _, decl := range d.decls {
+				for _, name := range boundNames(decl.target) {
+					sc.names[name] = true
+				}
+			}
+		case *funcDecl:
+			sc.names[d.name] = true
+		case *classDecl:
+			sc.names[d.name] = true
+		}
+	}
+}
+
+// Recorrer n dentro del ámbito sc; depth es el número de funciones que lo
+// contienen
+func (s *scopes) visit(n node, sc *scope, depth int) {
+	switch n := n.(type) {
+	case nil:
+		return
+	case *ident:
+		s.at[n] = sc
+		return
+	case *function:
+		fn := newScope(sc, depth == 0)
+		for _, p := range n.params {
+			for _, name := range boundNames(p.target) {
+				fn.names[name] = true
+			}
+		}
+		for _, p := range n.params {
+			s.pattern(p.target, fn, depth+1)
+			s.visit(p.def, fn, depth+1)
+		}
+		if n.body != nil {
+			s.declare(fn, n.body.body)
+			for _, stmt := range n.body.body {
+				s.visit(stmt, fn, depth+1)
+			}
+		}
+		s.visit(n.expr, fn, depth+1)
+		return
+	case *block:
+		inner := newScope(sc, false)
+		s.declare(inner, n.body)
+		for _, stmt := range n.body {
+			s.visit(stmt, inner, depth)
+		}
+		return
+	case *switchStmt:
+		s.visit(n.disc, sc, depth)
+		inner := newScope(sc, false)
+		for _, c := range n.cases {
+			s.declare(inner, c.body)
+		}
+		for _, c := range n.cases {
+			s.visit(c, inner, depth)
+		}
+		return
+	case *forStmt:
+		inner := newScope(sc, false)
+		s.declare(inner, []node{n.init})
+		for _, kid := range children(n) {
+			s.visit(kid, inner, depth)
+		}
+		return
+	case *forInStmt:
+		inner := newScope(sc, false)
+		s.declare(inner, []node{n.left})
+		for _, kid := range children(n) {
+			s.visit(kid, inner, depth)
+		}
+		return
+	case *tryStmt:
+		s.visit(n.block, sc, depth)
+		if n.handler != nil {
+			handler := newScope(sc, false)
+			for _, name := range boundNames(n.param) {
+				handler.names[name] = true
+			}
+			s.pattern(n.param, handler, depth)
+			s.visit(n.handler, handler, depth)
+		}
+		if n.finalizer != nil {
+			s.visit(n.finalizer, sc, depth)
+		}
+		return
+	case *declarator:
+		s.pattern(n.target, sc, depth)
+		s.visit(n.init, sc, depth)
+		return
+	case *property:
+		// La clave de { a: b } no es una referencia
+		if id, ok := n.key.(*ident); ok && !n.shorthand && !n.computed {
+			s.declared[id] = true
+			s.at[id] = sc
+			s.visit(n.value, sc, depth)
+			return
+		}
+	}
+	for _, kid := range children(n) {
+		s.visit(kid, sc, depth)
+	}
+}
+
+// Recorrer un patrón de declaración: sus identificadores declaran variables
+// y los valores por defecto son expresiones
+func (s *scopes) pattern(n node, sc *scope, depth int) {
+	switch n := n.(type) {
+	case *ident:
+		s.declared[n] = true
+		s.at[n] = sc
+	case *objectPattern:
+		for _, p := range n.props {
+			if p.computed {
+				s.visit(p.key, sc, depth)
+			} else if id, ok := p.key.(*ident); ok && !p.shorthand {
+				s.declared[id] = true
+				s.at[id] = sc
+			}
+			s.pattern(p.value, sc, depth)
+			s.visit(p.def, sc, depth)
+		}
+	case *arrayPattern:
+		for _, e := range n.elems {
+			if e != nil {
+				s.pattern(e.target, sc, depth)
+				s.visit(e.def, sc, depth)
+			}
+		}
+	default:
+		s.visit(n, sc, depth)
+	}
+}
@@ -0,0 +1,253 @@
+package transpiler
+
+import (
+	"strings"
+)
+
+// El contenido que un componente recibe como JSX se convierte en snippets de
+// Svelte: children pasa a ser de tipo Snippet y se renderiza con {@render},
+// y las render props (renderItem={(item) => <Row />}) y las funciones pasadas
+// como hijo se escriben como bloques {#snippet} dentro del componente hijo.
+
+// Tipos de React que describen contenido renderizable
+var nodeTypes = map[string]bool{
+	"ReactNode": true, "React.ReactNode": true, "ReactElement": true,
+	"React.ReactElement": true, "JSX.Element": true, "React.JSX.Element": true,
+}
+
+// Indica si el tipo es contenido renderizable, admitiendo | null y | undefined
+func isNodeType(typ string) bool {
+	found := false
+	for _, part := range splitTopLevel(typ, '|') {
+		switch part = strings.TrimSpace(part); part {
+		case "null", "undefined", "":
+		default:
+			if !nodeTypes[part] {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// renderItem, renderHeader...
+func isRenderName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "render")
+	return ok && rest != "" && rest[0] >= 'A' && rest[0] <= 'Z'
+}
+
+// Tipo Snippet equivalente al de una prop que recibe contenido: children,
+// las props de tipo ReactNode y las funciones que devuelven JSX
+func snippetType(name, typ string) (string, bool) {
+	typ = strings.TrimSpace(typ)
+	if params, result, ok := functionType(typ); ok {
+		if !isNodeType(result) {
+			return "", false
+		}
+		if len(params) == 0 {
+			return "Snippet", true
+		}
+		return "Snippet<[" + strings.Join(params, ", ") + "]>", true
+	}
+	switch {
+	case isNodeType(typ), name == "children" && typ == "any":
+		return "Snippet", true
+	case isRenderName(name) && typ == "any":
+		return "Snippet<any[]>", true
+	}
+	return "", false
+}
+
+// Tipos de los parámetros y tipo devuelto de (a: A, b: B) => R
+func functionType(typ string) ([]string, string, bool) {
+	typ = strings.TrimSpace(typ)
+	if strings.HasPrefix(typ, "(") && strings.HasSuffix(typ, ")") && matchingParen(typ, 0) == len(typ)-1 {
+		return functionType(typ[1 : len(typ)-1])
+	}
+	if !strings.HasPrefix(typ, "(") {
+		return nil, "", false
+	}
+	end := matchingParen(typ, 0)
+	if end < 0 {
+		return nil, "", false
+	}
+	result, ok := strings.CutPrefix(strings.TrimSpace(typ[end+1:]), "=>")
+	if !ok {
+		return nil, "", false
+	}
+	var params []string
+	for _, p := range splitTopLevel(typ[1:end], ',') {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		paramType := "any"
+		if i := strings.Index(p, ":"); i >= 0 {
+			paramType = strings.TrimSpace(p[i+1:])
+		}
+		params = append(params, paramType)
+	}
+	return params, strings.TrimSpace(result), true
+}
+
+// Posición del paréntesis que cierra el que está en open, o -1
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Convertir en Snippet el tipo de las props que reciben contenido y
+// recordarlas para renderizarlas con {@render}
+func (c *converter) extractSnippetProps(props []PropDefinition) {
+	for i, prop := range props {
+		typ, ok := snippetType(prop.Name, prop.Type)
+		if !ok {
+			continue
+		}
+		// children(data) sin tipo: función como hijo
+		if typ == "Snippet" && prop.Type == "any" && c.callsProp(prop.Name) {
+			typ = "Snippet<any[]>"
+		}
+		// children sin declarar en el tipo: lo aportan los atributos heredados
+		if prop.Name == "children" && prop.Type == "any" {
+			props[i].Optional = true
+		}
+		props[i].Type = typ
+		c.snippetProps[prop.Name] = !prop.InRest
+		c.svelteImports["type Snippet"] = true
+	}
+}
+
+// Indica si el componente llama a la prop como función
+func (c *converter) callsProp(name string) bool {
+	if c.decl == nil {
+		return false
+	}
+	called := false
+	walk(c.decl.fn, func(n node) bool {
+		if call, ok := n.(*callExpr); ok {
+			if callee, ok := c.propName(call.callee); ok && callee == name {
+				called = true
+			}
+		}
+		return !called
+	})
+	return called
+}
+
+// Indica si la expresión lee una prop que recibe contenido: children,
+// props.children o rest.children
+func (c *converter) isSnippetProp(n node) bool {
+	switch e := unparen(n).(type) {
+	case *ident:
+		return c.snippetProps[e.name]
+	case *memberExpr:
+		name, ok := c.propName(e)
+		_, isSnippet := c.snippetProps[name]
+		return ok && isSnippet
+	}
+	return false
+}
+
+// {children} -> {@render children?.()}, {renderItem(item)} -> {@render renderItem(item)}
+func (c *converter) renderTag(expr node) (string, bool) {
+	switch e := unparen(expr).(type) {
+	case *ident, *memberExpr:
+		if c.isSnippetProp(e) {
+			return "{@render " + c.print(e) + "?.()}", true
+		}
+	case *callExpr:
+		if c.isSnippetProp(e.callee) {
+			return "{@render " + c.print(e) + "}", true
+		}
+	}
+	return "", false
+}
+
+// Contenido JSX que se pasa a un componente como snippet; fn es nil si se
+// pasa el JSX directamente
+type snippetArg struct {
+	name string
+	fn   *function
+	body node
+}
+
+// Render props con una función que devuelve JSX, props que reciben JSX
+// (icon={<Star />}) y función pasada como hijo; child indica que la función
+// ocupa el lugar de los hijos
+func (c *converter) elementSnippets(el *jsxElement) (snippets []snippetArg, child bool) {
+	if isHTMLName(el.name) || c.isTransparent(el) {
+		return nil, false
+	}
+	for _, a := range el.attrs {
+		attr, ok := a.(*jsxAttr)
+		if !ok {
+			continue
+		}
+		container, ok := attr.value.(*jsxExprContainer)
+		if !ok || container.expr == nil {
+			continue
+		}
+		if fn, ok := unparen(container.expr).(*function); ok {
+			if body := jsxResult(fn); body != nil {
+				c.skip[attr] = true
+				snippets = append(snippets, snippetArg{name: attr.name, fn: fn, body: body})
+			}
+		}
+		if body := unparen(container.expr); isJSX(body) {
+			c.skip[attr] = true
+			snippets = append(snippets, snippetArg{name: attr.name, body: body})
+		}
+	}
+
+	// <DataLoader>{(data) => <Chart data={data} />}</DataLoader>
+	var fnChild *function
+	for _, kid := range el.children {
+		switch kid := kid.(type) {
+		case *jsxText:
+			if strings.TrimSpace(kid.raw) == "" {
+				continue
+			}
+		case *jsxExprContainer:
+			if fn, ok := unparen(kid.expr).(*function); ok && fnChild == nil {
+				fnChild = fn
+				continue
+			}
+		}
+		return snippets, false
+	}
+	if fnChild != nil {
+		if body := jsxResult(fnChild); body != nil {
+			return append(snippets, snippetArg{name: "children", fn: fnChild, body: body}), true
+		}
+	}
+	return snippets, false
+}
+
+// Bloques {#snippet} de un componente, uno por línea con la indentación de
+// sus hijos
+func (c *converter) snippetArgs(el *jsxElement, snippets []snippetArg) string {
+	inner := lineIndent(c.src, el.start) + "  "
+	var b strings.Builder
+	for _, s := range snippets {
+		params := "()"
+		if s.fn != nil {
+			params = c.functionParams(s.fn)
+		}
+		b.WriteString("\n" + inner + "{#snippet " + s.name + params + "}\n")
+		b.WriteString(c.blockContent(s.body, inner))
+		b.WriteString(inner + "{/snippet}")
+	}
+	return b.String()
+}
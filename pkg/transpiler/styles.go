@@ -0,0 +1,191 @@
+package transpiler
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// style={{ marginTop: 8, color }} no es válido en Svelte, que solo acepta
+// cadenas: los valores estáticos se escriben como style="margin-top: 8px" y
+// los dinámicos como directivas style:color={color}. Los números reciben px
+// salvo en las propiedades sin unidad, igual que en React.
+
+// Propiedades CSS cuyos valores numéricos no llevan unidad
+var unitlessProperties = map[string]bool{
+	"animationIterationCount": true, "aspectRatio": true, "borderImageOutset": true,
+	"borderImageSlice": true, "borderImageWidth": true, "boxFlex": true, "boxFlexGroup": true,
+	"boxOrdinalGroup": true, "columnCount": true, "columns": true, "flex": true,
+	"flexGrow": true, "flexPositive": true, "flexShrink": true, "flexNegative": true,
+	"flexOrder": true, "gridArea": true, "gridRow": true, "gridRowEnd": true,
+	"gridRowSpan": true, "gridRowStart": true, "gridColumn": true, "gridColumnEnd": true,
+	"gridColumnSpan": true, "gridColumnStart": true, "fontWeight": true, "lineClamp": true,
+	"lineHeight": true, "opacity": true, "order": true, "orphans": true, "scale": true,
+	"tabSize": true, "widows": true, "zIndex": true, "zoom": true, "fillOpacity": true,
+	"floodOpacity": true, "stopOpacity": true, "strokeDasharray": true,
+	"strokeDashoffset": true, "strokeMiterlimit": true, "strokeOpacity": true,
+	"strokeWidth": true,
+}
+
+// Prefijos de proveedor: WebkitTransition, msTransform...
+var vendorPrefix = regexp.MustCompile(`^(Webkit|Moz|O|ms)([A-Z])`)
+
+// Indica si los números de la propiedad se escriben sin unidad
+func isUnitless(property string) bool {
+	if m := vendorPrefix.FindStringSubmatch(property); m != nil {
+		property = strings.ToLower(m[2]) + property[len(m[0]):]
+	}
+	return unitlessProperties[property] || strings.HasPrefix(property, "--")
+}
+
+// marginTop -> margin-top, WebkitTransition -> -webkit-transition
+func cssProperty(property string) string {
+	if strings.HasPrefix(property, "--") {
+		return property
+	}
+	var b strings.Builder
+	if vendorPrefix.MatchString(property) {
+		b.WriteByte('-')
+	}
+	for i, r := range property {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Valor CSS de un literal: cadenas sin comillas y números con px cuando la
+// propiedad lo necesita; devuelve false si el valor no es estático
+func (c *converter) staticStyleValue(property string, value node) (string, bool) {
+	switch v := unparen(value).(type) {
+	case *literal:
+		switch v.kind {
+		case litString:
+			return v.raw[1 : len(v.raw)-1], true
+		case litNumber:
+			if isUnitless(property) || v.raw == "0" {
+				return v.raw, true
+			}
+			return v.raw + "px", true
+		}
+	case *unaryExpr:
+		if lit, ok := v.arg.(*literal); ok && v.op == "-" && lit.kind == litNumber {
+			text, _ := c.staticStyleValue(property, lit)
+			return "-" + text, true
+		}
+	case *templateLit:
+		if len(v.exprs) == 0 {
+			raw := nodeText(c.src, v)
+			return raw[1 : len(raw)-1], true
+		}
+	}
+	return "", false
+}
+
+// Propiedades del DOM y de las medidas que siempre son números
+var numericMembers = map[string]bool{
+	"top": true, "left": true, "right": true, "bottom": true, "width": true, "height": true,
+	"x": true, "y": true, "length": true, "offsetTop": true, "offsetLeft": true,
+	"offsetWidth": true, "offsetHeight": true, "clientWidth": true, "clientHeight": true,
+	"scrollTop": true, "scrollLeft": true, "scrollX": true, "scrollY": true,
+	"innerWidth": true, "innerHeight": true, "clientX": true, "clientY": true,
+	"pageX": true, "pageY": true,
+}
+
+// 8, -4, 1.5
+func isNumberLiteral(text string) bool {
+	_, err := strconv.ParseFloat(text, 64)
+	return err == nil
+}
+
+// Indica si la expresión es un número, para añadirle px en una directiva
+func (c *converter) isNumeric(n node) bool {
+	switch v := unparen(n).(type) {
+	case *literal:
+		return v.kind == litNumber
+	case *unaryExpr:
+		return v.op == "-" && c.isNumeric(v.arg)
+	case *binaryExpr:
+		switch v.op {
+		case "-", "*", "/", "%":
+			return true
+		case "+":
+			return c.isNumeric(v.left) && c.isNumeric(v.right)
+		}
+	case *conditionalExpr:
+		return c.isNumeric(v.cons) && c.isNumeric(v.alt)
+	case *callExpr:
+		if m, ok := v.callee.(*memberExpr); ok {
+			obj, ok := m.object.(*ident)
+			return ok && obj.name == "Math"
+		}
+	case *memberExpr:
+		return numericMembers[v.property]
+	case *ident:
+		return c.numbers[v.name]
+	}
+	return false
+}
+
+// style={{ ... }} en un elemento HTML: atributo style con la parte estática y
+// una directiva style: por cada valor dinámico
+func (c *converter) printStyle(attr *jsxAttr) (string, bool) {
+	container, ok := attr.value.(*jsxExprContainer)
+	if !ok || container.expr == nil {
+		return "", false
+	}
+	obj, ok := unparen(container.expr).(*objectLit)
+	if !ok {
+		c.warn(attr, CodeStyleObject, "el atributo style recibe un objeto que Svelte no admite; conviértalo en una cadena CSS")
+		return "", false
+	}
+
+	var declarations, directives []string
+	for _, prop := range obj.props {
+		var name string
+		switch key := prop.key.(type) {
+		case *ident:
+			name = key.name
+		case *literal:
+			if key.kind == litString {
+				name = key.raw[1 : len(key.raw)-1]
+			}
+		}
+		if name == "" || prop.spread || prop.computed {
+			c.warn(prop, CodeStyleObject, "propiedad de estilo no convertida; el objeto style solo admite claves fijas")
+			return "", false
+		}
+
+		property := cssProperty(name)
+		if value, ok := c.staticStyleValue(name, prop.value); ok {
+			declarations = append(declarations, property+": "+value)
+			continue
+		}
+		value := c.print(prop.value)
+		switch {
+		case !isUnitless(name) && c.isNumeric(prop.value):
+			directives = append(directives, "style:"+property+"=\"{"+value+"}px\"")
+		case prop.shorthand && property == name:
+			directives = append(directives, "style:"+property)
+		default:
+			directives = append(directives, "style:"+property+"={"+value+"}")
+		}
+	}
+
+	var parts []string
+	if len(declarations) > 0 || len(directives) == 0 {
+		css := strings.Join(declarations, "; ")
+		quote := "\""
+		if strings.Contains(css, "\"") {
+			quote = "'"
+		}
+		parts = append(parts, "style="+quote+css+quote)
+	}
+	return strings.Join(append(parts, directives...), " "), true
+}
@@ -0,0 +1,18 @@
+import { useState } from 'react';
+
+function useDisclosure(initial = false) {
+  const [open, setOpen] = useState(initial);
+  const toggle = () => setOpen(!open);
+  return { open, toggle };
+}
+
+export default function Accordion({ title, children }: { title: string; children: React.ReactNode }) {
+  const { open, toggle } = useDisclosure();
+
+  return (
+    <section className="accordion">
+      <button onClick={toggle} aria-expanded={open}>{title}</button>
+      {open && <div className="panel">{children}</div>}
+    </section>
+  );
+}
@@ -0,0 +1,17 @@
+import { useContext } from 'react';
+import { AuthContext } from './AuthContext';
+
+export default function AdminMenu() {
+  const { user } = useContext(AuthContext);
+  const isAdmin = user?.roles.includes('admin') ?? false;
+
+  if (!isAdmin) {
+    return null;
+  }
+
+  return (
+    <nav className="admin-menu">
+      <a href="/admin/users">Usuarios de {user.name}</a>
+    </nav>
+  );
+}
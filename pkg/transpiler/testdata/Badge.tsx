@@ -0,0 +1,8 @@
+type BadgeProps = {
+  label: string;
+  tone?: 'info' | 'warning';
+};
+
+const Badge = ({ label, tone = 'info' }: BadgeProps) => <span className={`badge badge-${tone}`}>{label}</span>;
+
+export default Badge;
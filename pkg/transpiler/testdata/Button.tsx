@@ -0,0 +1,14 @@
+import React from 'react';
+
+interface ButtonProps extends React.ButtonHTMLAttributes<HTMLButtonElement> {
+  variant?: 'primary' | 'ghost';
+  loading?: boolean;
+}
+
+export default function Button({ variant = 'primary', loading = false, children, ...rest }: ButtonProps) {
+  return (
+    <button className={`btn btn-${variant}`} disabled={loading} {...rest}>
+      {loading ? <span className="spinner" /> : children}
+    </button>
+  );
+}
@@ -0,0 +1,16 @@
+import React, { memo } from 'react';
+
+type CardProps = {
+  title: string;
+  subtitle?: string;
+  highlighted: boolean;
+};
+
+const Card: React.FC<CardProps> = ({ title, subtitle, highlighted }) => (
+  <article className={highlighted ? 'card card-highlighted' : 'card'}>
+    <h3>{title}</h3>
+    {subtitle && <p>{subtitle}</p>}
+  </article>
+);
+
+export default memo(Card);
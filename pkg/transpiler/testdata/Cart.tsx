@@ -0,0 +1,34 @@
+import React, { useMemo, useState } from 'react';
+
+interface Item {
+  id: number;
+  price: number;
+  quantity: number;
+}
+
+export default function Cart({ items, taxRate }: { items: Item[]; taxRate: number }) {
+  const [coupon, setCoupon] = useState('');
+
+  const subtotal = useMemo(() => items.reduce((sum, item) => sum + item.price * item.quantity, 0), [items]);
+
+  const discount = useMemo<number>(() => {
+    if (coupon === 'HALF') {
+      return subtotal / 2;
+    }
+    return 0;
+  }, [coupon, subtotal]);
+
+  const { total, tax } = useMemo(() => {
+    const tax = (subtotal - discount) * taxRate;
+    return { total: subtotal - discount + tax, tax };
+  }, [subtotal, discount, taxRate]);
+
+  return (
+    <section>
+      <input value={coupon} onChange={e => setCoupon(e.target.value)} />
+      <p>Subtotal: {subtotal.toFixed(2)}</p>
+      <p>Impuestos: {tax.toFixed(2)}</p>
+      <p>Total: {total.toFixed(2)}</p>
+    </section>
+  );
+}
@@ -0,0 +1,23 @@
+import { useCart } from './useCart';
+import useToggle from './useToggle';
+
+export default function CartSummary() {
+  const { items, total, clear } = useCart();
+  const [open, toggle] = useToggle(false);
+  const label = `${items.length} productos`;
+
+  return (
+    <aside className="cart">
+      <button onClick={toggle}>{label}</button>
+      {open && (
+        <ul>
+          {items.map((item) => (
+            <li key={item.product.id}>{item.product.name}</li>
+          ))}
+        </ul>
+      )}
+      <p>Total: {total}</p>
+      <button onClick={clear}>Vaciar</button>
+    </aside>
+  );
+}
@@ -0,0 +1,17 @@
+import { useContext, useState } from 'react';
+import { AuthContext } from './AuthContext';
+import { useCart } from './useCart';
+
+export default function Checkout({ price }: { price: number }) {
+  const user = useContext(AuthContext);
+  const { items, total } = useCart(user.id);
+  const doubled = price * 2;
+  const [amount, setAmount] = useState(doubled);
+
+  return (
+    <form>
+      <p>{user.name}: {items.length} productos, {total} €</p>
+      <input type="number" value={amount} onChange={(e) => setAmount(Number(e.target.value))} />
+    </form>
+  );
+}
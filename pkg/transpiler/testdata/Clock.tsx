@@ -0,0 +1,30 @@
+import { useEffect, useState } from 'react';
+
+export default function Clock({ interval, label }: { interval: number; label: string }) {
+  const [now, setNow] = useState(new Date());
+  const [ticks, setTicks] = useState(0);
+
+  useEffect(() => {
+    const id = setInterval(() => {
+      setNow(new Date());
+      setTicks(t => t + 1);
+    }, interval);
+    return () => clearInterval(id);
+  }, [interval]);
+
+  useEffect(() => {
+    console.log(`${label}: ${ticks}`);
+  }, [ticks]);
+
+  useEffect(() => {
+    document.title = label;
+  }, [label]);
+
+  useEffect(() => {
+    const onKey = (e: KeyboardEvent) => e.key === 'r' && setTicks(0);
+    window.addEventListener('keydown', onKey);
+    return () => window.removeEventListener('keydown', onKey);
+  }, []);
+
+  return <time>{now.toLocaleTimeString()} ({ticks})</time>;
+}
@@ -0,0 +1,53 @@
+import { createContext, useContext, useReducer, useState } from 'react';
+
+type Action = { type: 'add' } | { type: 'reset' };
+
+const MAX = 10;
+
+function reducer(state: number, action: Action) {
+  switch (action.type) {
+    case 'add':
+      return Math.min(state + 1, MAX);
+    default:
+      return 0;
+  }
+}
+
+const CountContext = createContext<{ count: number; dispatch: (action: Action) => void } | null>(null);
+
+function useToggle(initial: boolean) {
+  const [on, setOn] = useState(initial);
+  const toggle = () => setOn(!on);
+  return { on, toggle };
+}
+
+function Display() {
+  const ctx = useContext(CountContext);
+  const { on, toggle } = useToggle(true);
+  return (
+    <p onClick={toggle}>
+      {on ? ctx?.count : '—'} / {MAX}
+    </p>
+  );
+}
+
+function Controls() {
+  const ctx = useContext(CountContext);
+  const reset: Action = { type: 'reset' };
+  return (
+    <div className="controls">
+      <button onClick={() => ctx?.dispatch({ type: 'add' })}>Sumar</button>
+      <button onClick={() => ctx?.dispatch(reset)}>Reiniciar</button>
+    </div>
+  );
+}
+
+export default function CountStore() {
+  const [count, dispatch] = useReducer(reducer, 0);
+  return (
+    <CountContext.Provider value={{ count, dispatch }}>
+      <Display />
+      <Controls />
+    </CountContext.Provider>
+  );
+}
@@ -0,0 +1,28 @@
+import React, { useState, useEffect } from 'react';
+
+export default function Counter() {
+  const [count, setCount] = useState(0);
+  const [step, setStep] = useState<number>(1);
+
+  useEffect(() => {
+    document.title = `Clicks: ${count}`;
+  }, [count]);
+
+  const increment = () => setCount(count + step);
+  const decrement = () => setCount(prev => prev - step);
+
+  function reset() {
+    setCount(0);
+    setStep(1);
+  }
+
+  return (
+    <div className="counter">
+      <p>Valor: {count}</p>
+      <input type="number" value={step} onChange={e => setStep(Number(e.target.value))} />
+      <button onClick={decrement}>-</button>
+      <button onClick={increment}>+</button>
+      <button onClick={reset}>Reiniciar</button>
+    </div>
+  );
+}
@@ -0,0 +1,25 @@
+import DataTable from './DataTable';
+import DataLoader from './DataLoader';
+import Panel from './Panel';
+
+export default function Dashboard({ rows }: { rows: Row[] }) {
+  return (
+    <Panel title="Resumen">
+      <p>Últimos movimientos</p>
+      <DataTable
+        title="Movimientos"
+        rows={rows}
+        renderRow={(row: Row, index: number) => <span>{index + 1}. {row.label}</span>}
+        renderEmpty={() => <em>Sin datos</em>}
+      />
+      <DataLoader url="/api/stats">
+        {(stats: Stats) => (
+          <dl>
+            <dt>Total</dt>
+            <dd>{stats.total}</dd>
+          </dl>
+        )}
+      </DataLoader>
+    </Panel>
+  );
+}
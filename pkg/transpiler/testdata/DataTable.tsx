@@ -0,0 +1,24 @@
+import type { ReactNode } from 'react';
+
+interface DataTableProps {
+  title: string;
+  rows: Row[];
+  renderRow: (row: Row, index: number) => ReactNode;
+  renderEmpty?: () => ReactNode;
+  children?: ReactNode;
+}
+
+export default function DataTable({ title, rows, renderRow, renderEmpty, children }: DataTableProps) {
+  return (
+    <section className="table">
+      <h2>{title}</h2>
+      {children}
+      {rows.length === 0 && renderEmpty?.()}
+      <ul>
+        {rows.map((row, i) => (
+          <li key={row.id}>{renderRow(row, i)}</li>
+        ))}
+      </ul>
+    </section>
+  );
+}
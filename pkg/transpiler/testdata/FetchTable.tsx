@@ -0,0 +1,40 @@
+import { useEffect, useState } from 'react';
+
+type Row = { id: number; name: string };
+
+function useFetch(url: string) {
+  const [data, setData] = useState<Row[]>([]);
+  const [loading, setLoading] = useState(true);
+
+  useEffect(() => {
+    fetch(url)
+      .then((res) => res.json())
+      .then((data: Row[]) => {
+        setData(data);
+        setLoading(false);
+      });
+  }, [url]);
+
+  return { data, loading };
+}
+
+export default function FetchTable({ url }: { url: string }) {
+  const { data, loading } = useFetch(url);
+  const label = (loading: boolean) => (loading ? 'Cargando...' : 'Listo');
+  const count = () => {
+    const data = 0;
+    return data;
+  };
+
+  return (
+    <div>
+      <p>{label(loading)}</p>
+      <p>{count()}</p>
+      <ul>
+        {data.map((data) => (
+          <li key={data.id}>{data.name}</li>
+        ))}
+      </ul>
+    </div>
+  );
+}
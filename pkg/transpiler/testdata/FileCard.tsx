@@ -0,0 +1,39 @@
+import Tooltip from './Tooltip';
+
+interface FileCardProps {
+  file: { id: string; name: string; description: string };
+  onOpen: (id: string) => void;
+  onRename: (name: string) => void;
+}
+
+export default function FileCard({ file, onOpen, onRename }: FileCardProps) {
+  return (
+    <div
+      className="file-card"
+      tabIndex={0}
+      draggable
+      onDoubleClick={() => onOpen(file.id)}
+      onDragStart={e => e.dataTransfer.setData('text/plain', file.id)}
+      onClickCapture={e => e.stopPropagation()}
+      suppressHydrationWarning
+    >
+      <svg viewBox="0 0 24 24" width={24} height={24} aria-hidden="true">
+        <use xlinkHref="#icon-file" />
+        <path d="M4 4h16v16H4z" fill="none" strokeWidth={2} strokeLinecap="round" />
+      </svg>
+      <label htmlFor={`name-${file.id}`}>Nombre</label>
+      <input
+        id={`name-${file.id}`}
+        defaultValue={file.name}
+        maxLength={80}
+        autoFocus
+        spellCheck={false}
+        onInput={e => onRename(e.currentTarget.value)}
+        onPointerDown={e => e.currentTarget.select()}
+      />
+      <input value={file.id} readOnly />
+      <div className="description" onScroll={e => console.log(e.currentTarget.scrollTop)} dangerouslySetInnerHTML={{ __html: file.description }} />
+      <Tooltip className="hint" onClick={() => onOpen(file.id)} text="Abrir" />
+    </div>
+  );
+}
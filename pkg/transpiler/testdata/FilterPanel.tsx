@@ -0,0 +1,35 @@
+import { useCallback, useState } from 'react';
+
+interface FilterPanelProps {
+  options: string[];
+  onApply: (selected: string[]) => Promise<void>;
+}
+
+export default function FilterPanel({ options, onApply }: FilterPanelProps) {
+  const [selected, setSelected] = useState<string[]>([]);
+  const limit = options.length;
+
+  const toggle = useCallback((option: string) => {
+    setSelected(prev => (prev.includes(option) ? prev.filter(o => o !== option) : [...prev, option]));
+  }, []);
+
+  const apply = useCallback(async (): Promise<void> => {
+    if (selected.length > limit) return;
+    await onApply(selected);
+  }, [selected, limit, onApply]);
+
+  const clear = useCallback(() => setSelected([]), []);
+
+  return (
+    <div className="filters">
+      {options.map(option => (
+        <label key={option}>
+          <input type="checkbox" checked={selected.includes(option)} onChange={() => toggle(option)} />
+          {option}
+        </label>
+      ))}
+      <button onClick={apply}>Aplicar</button>
+      <button onClick={clear}>Limpiar</button>
+    </div>
+  );
+}
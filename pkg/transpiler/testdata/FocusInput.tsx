@@ -0,0 +1,32 @@
+import { useRef, useState } from 'react';
+
+export default function FocusInput() {
+  const inputRef = useRef<HTMLInputElement>(null);
+  const canvasRef = useRef(null);
+  const renders = useRef(0);
+  const timer = useRef<number | null>(null);
+  const [value, setValue] = useState('');
+
+  renders.current += 1;
+
+  const focus = () => {
+    inputRef.current?.focus();
+    const ctx = canvasRef.current.getContext('2d');
+    ctx.fillRect(0, 0, 10, 10);
+  };
+
+  function schedule() {
+    if (timer.current) clearTimeout(timer.current);
+    timer.current = window.setTimeout(() => setValue(''), 1000);
+  }
+
+  return (
+    <div>
+      <input ref={inputRef} value={value} onChange={e => setValue(e.target.value)} />
+      <canvas ref={canvasRef} width={10} height={10} />
+      <button onClick={focus}>Enfocar</button>
+      <button onClick={schedule}>Limpiar en 1s</button>
+      <p>Renders: {renders.current}</p>
+    </div>
+  );
+}
@@ -0,0 +1,15 @@
+type IconLinkProps = {
+  icon: string;
+  label: string;
+  external?: boolean;
+};
+
+export default function IconLink({ icon, label, external, ...rest }: IconLinkProps & { href: string }) {
+  const target = external ? { target: '_blank', rel: 'noreferrer' } : {};
+  return (
+    <a {...rest} {...target} {...{ className: 'icon-link', onClick: () => console.log(label) }}>
+      <i className={`icon icon-${icon}`} />
+      {label}
+    </a>
+  );
+}
@@ -0,0 +1,27 @@
+import { useState } from 'react';
+
+export function Currency({ code }: { code: string }) {
+  return <abbr title={code}>{code === 'EUR' ? '€' : '$'}</abbr>;
+}
+
+function Amount({ value, code }: { value: number; code: string }) {
+  return <strong>{value.toFixed(2)} <Currency code={code} /></strong>;
+}
+
+export default function Invoice({ lines }: { lines: { id: string; price: number }[] }) {
+  const [code, setCode] = useState('EUR');
+
+  return (
+    <div className="invoice">
+      <button onClick={() => setCode(code === 'EUR' ? 'USD' : 'EUR')}>Cambiar moneda</button>
+      <ul>
+        {lines.map((line) => (
+          <li key={line.id}>
+            <Amount key={line.id} value={line.price} code={code} />
+          </li>
+        ))}
+      </ul>
+      <Amount key="total" value={lines.reduce((sum, line) => sum + line.price, 0)} code={code} />
+    </div>
+  );
+}
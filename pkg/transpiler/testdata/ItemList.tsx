@@ -0,0 +1,9 @@
+import { List } from './List';
+
+export default function ItemList({ items }: { items: number[] }) {
+  return (
+    <div className="items">
+      <List items={items} renderItem={(x: number) => <b>{x}</b>}></List>
+    </div>
+  );
+}
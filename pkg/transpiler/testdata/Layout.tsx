@@ -0,0 +1,41 @@
+import React, { Fragment, useState } from 'react';
+import { Link } from './Link';
+import type { ReactNode } from 'react';
+
+function formatTitle(title: string): string {
+  return title.trim().toUpperCase();
+}
+
+interface LayoutProps {
+  title: string;
+  sections: { id: number; label: string; items: string[] }[];
+}
+
+export default function Layout({ title, sections }: LayoutProps) {
+  const [open, setOpen] = useState<number | null>(null);
+
+  return (
+    <>
+      <header>
+        <h1>{formatTitle(title)}</h1>
+        <div className="divider" />
+      </header>
+      {/*
+        Cada sección se puede desplegar
+      */}
+      {sections.map(section => (
+        <Fragment key={section.id}>
+          <h2 onMouseOver={() => setOpen(section.id)} onMouseOut={() => setOpen(null)}>
+            {section.label}
+          </h2>
+          {open === section.id && (
+            <ul>
+              {section.items.map((item, i) => <li key={i}><Link to={item}>{item}</Link></li>)}
+            </ul>
+          )}
+        </Fragment>
+      ))}
+      <footer onKeyUp={e => e.key === 'Enter' && setOpen(null)}>© {new Date().getFullYear()}</footer>
+    </>
+  );
+}
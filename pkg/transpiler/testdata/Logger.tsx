@@ -0,0 +1,24 @@
+import { useCallback, useState } from 'react';
+
+export default function Logger({ source }: { source: string }) {
+  const [entries, setEntries] = useState<string[]>([]);
+  const openedAt = Date.now();
+
+  const log = useCallback(
+    (message: string) => {
+      setEntries([...entries, `${source} +${Date.now() - openedAt}ms: ${message}`]);
+    },
+    [entries, source, openedAt],
+  );
+
+  return (
+    <div>
+      <button onClick={() => log('clic')}>Registrar</button>
+      <ol>
+        {entries.map((entry, i) => (
+          <li key={i}>{entry}</li>
+        ))}
+      </ol>
+    </div>
+  );
+}
@@ -0,0 +1,17 @@
+interface NoteInputProps {
+  onDraft: (text: string) => void;
+  onCommit: (text: string) => void;
+}
+
+export default function NoteInput({ onDraft, onCommit }: NoteInputProps) {
+  return (
+    <label>
+      Nota
+      <input
+        onInput={(e) => onDraft(e.currentTarget.value)}
+        onChange={(e) => onCommit(e.target.value)}
+      />
+      <textarea onChange={(e) => onDraft(e.target.value)} />
+    </label>
+  );
+}
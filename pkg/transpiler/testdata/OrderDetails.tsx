@@ -0,0 +1,29 @@
+import { useState } from 'react';
+import Spinner from './Spinner';
+
+interface OrderDetailsProps {
+  order?: Order;
+  loading: boolean;
+  error?: string;
+}
+
+export default function OrderDetails({ order, loading, error }: OrderDetailsProps) {
+  const [expanded, setExpanded] = useState(false);
+
+  if (loading) return <Spinner />;
+  if (error) {
+    return (
+      <div className="alert">
+        <strong>Error:</strong> {error}
+      </div>
+    );
+  }
+  if (!order) return null;
+
+  const total = order.items.reduce((sum, item) => sum + item.price, 0);
+
+  return <section className="order">
+    <h2 onClick={() => setExpanded(!expanded)}>Pedido #{order.id}</h2>
+    {expanded && <p>Total: {total}</p>}
+  </section>;
+}
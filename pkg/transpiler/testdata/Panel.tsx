@@ -0,0 +1,17 @@
+import type { ReactNode } from 'react';
+import { Frame } from './Frame';
+
+type PanelProps = {
+  title: string;
+  collapsed?: boolean;
+  children: ReactNode;
+};
+
+export default function Panel(props: PanelProps) {
+  return (
+    <Frame {...props}>
+      <h2>{props.title}</h2>
+      {!props.collapsed && props.children}
+    </Frame>
+  );
+}
@@ -0,0 +1,50 @@
+import { useState } from 'react';
+
+interface Product {
+  id: number;
+  name: string;
+  price: number;
+}
+
+interface ProductRowProps {
+  product: Product;
+  onRemove: (id: number) => void;
+}
+
+function Price({ amount, currency = 'EUR' }: { amount: number; currency?: string }) {
+  return <span className="price">{amount.toFixed(2)} {currency}</span>;
+}
+
+function ProductRow({ product, onRemove }: ProductRowProps) {
+  const [confirming, setConfirming] = useState(false);
+
+  return (
+    <li>
+      {product.name}
+      {confirming ? (
+        <button onClick={() => onRemove(product.id)}>Confirmar</button>
+      ) : (
+        <button onClick={() => setConfirming(true)}>Eliminar</button>
+      )}
+    </li>
+  );
+}
+
+export default function ProductList({ products }: { products: Product[] }) {
+  const [items, setItems] = useState(products);
+
+  const remove = (id: number) => {
+    setItems(items.filter((item) => item.id !== id));
+  };
+
+  return (
+    <section>
+      <ul>
+        {items.map((product) => (
+          <ProductRow key={product.id} product={product} onRemove={remove} />
+        ))}
+      </ul>
+      <Price amount={items.reduce((sum, item) => sum + item.price, 0)} />
+    </section>
+  );
+}
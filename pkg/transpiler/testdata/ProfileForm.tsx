@@ -0,0 +1,29 @@
+import { useState } from 'react';
+
+export default function ProfileForm({ onSave }: { onSave: (profile: Profile) => void }) {
+  const [form, setForm] = useState({ name: '', email: '' });
+  const [bio, setBio] = useState('');
+  const [role, setRole] = useState('user');
+  const [age, setAge] = useState(18);
+  const [subscribed, setSubscribed] = useState(false);
+  const [nickname, setNickname] = useState('');
+
+  return (
+    <form onSubmit={e => { e.preventDefault(); onSave({ ...form, bio, role, age, subscribed }); }}>
+      <input value={form.name} onChange={e => setForm({ ...form, name: e.target.value })} />
+      <input type="email" value={form.email} onChange={e => setForm({ ...form, email: e.target.value })} />
+      <textarea value={bio} onChange={(e) => { setBio(e.currentTarget.value); }} />
+      <select value={role} onChange={e => setRole(e.target.value)}>
+        <option value="user">Usuario</option>
+        <option value="admin">Administrador</option>
+      </select>
+      <input type="range" min={0} max={120} value={age} onChange={e => setAge(e.target.valueAsNumber)} />
+      <label>
+        <input type="checkbox" checked={subscribed} onChange={e => setSubscribed(e.target.checked)} />
+        Recibir novedades
+      </label>
+      <input value={nickname} onChange={e => setNickname(e.target.value.toLowerCase())} />
+      <button type="submit">Guardar</button>
+    </form>
+  );
+}
@@ -0,0 +1,28 @@
+import { useState } from 'react';
+
+type ProfileHeaderProps = {
+  first: string;
+  last: string;
+  bio: string;
+  tags: string[];
+};
+
+export default function ProfileHeader({ first, last, bio, tags }: ProfileHeaderProps) {
+  const [compact, setCompact] = useState(false);
+
+  const fullName = first + ' ' + last;
+  const initials: string = `${first[0]}${last[0]}`.toUpperCase();
+  const shortBio = compact && bio.length > 140 ? bio.slice(0, 140) + '…' : bio;
+  const [mainTag, ...otherTags] = tags;
+  const style = { label: fullName, count: otherTags.length };
+  const heading = compact ? initials : fullName;
+
+  return (
+    <header title={style.label}>
+      <h1 onClick={() => setCompact(!compact)}>{heading}</h1>
+      <p>{shortBio}</p>
+      {mainTag && <span className="tag">{mainTag}</span>}
+      <small>+{style.count}</small>
+    </header>
+  );
+}
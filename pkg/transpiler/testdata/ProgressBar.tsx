@@ -0,0 +1,26 @@
+import { useState } from 'react';
+
+interface ProgressBarProps {
+  value: number;
+  color: string;
+  label?: string;
+}
+
+export default function ProgressBar({ value, color, label }: ProgressBarProps) {
+  const [height, setHeight] = useState(8);
+  const shadow = '0 1px 2px rgba(0, 0, 0, 0.2)';
+
+  return (
+    <div
+      className="progress"
+      style={{ marginTop: 16, padding: '4px 8px', borderRadius: 4, opacity: 0.9, zIndex: 2 }}
+      onClick={() => setHeight(height === 8 ? 16 : 8)}
+    >
+      <div
+        className="progress-bar"
+        style={{ width: `${value}%`, height, backgroundColor: color, boxShadow: shadow, WebkitTransition: 'width 0.3s', '--accent': color }}
+      />
+      {label && <span style={{ fontFamily: '"Inter", sans-serif', lineHeight: 1.5, marginLeft: -4 }}>{label}</span>}
+    </div>
+  );
+}
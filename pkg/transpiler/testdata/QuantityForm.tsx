@@ -0,0 +1,13 @@
+import { useState } from 'react';
+
+export default function QuantityForm() {
+  const [quantity, setQuantity] = useState(1);
+  const [color, setColor] = useState(0);
+
+  return (
+    <form>
+      <input type="number" value={quantity} onChange={(e) => setQuantity(parseInt(e.target.value, 10))} />
+      <input type="number" value={color} onChange={(e) => setColor(parseInt(e.target.value, 16))} />
+    </form>
+  );
+}
@@ -0,0 +1,29 @@
+import { useReducer } from 'react';
+
+type Score = { home: number; away: number };
+type Goal = { team: 'home' | 'away' };
+
+function scoreReducer(score: Score, goal: Goal): Score {
+  return { ...score, [goal.team]: score[goal.team] + 1 };
+}
+
+function countReducer(count: number): number {
+  return count + 1;
+}
+
+export default function Scoreboard() {
+  const [{ home, away }, addGoal] = useReducer(scoreReducer, { home: 0, away: 0 });
+  const [renders] = useReducer(countReducer, 0);
+  const leader = home === away ? 'Empate' : home > away ? 'Local' : 'Visitante';
+
+  return (
+    <div className="scoreboard">
+      <p>
+        {home} - {away} ({leader})
+      </p>
+      <button onClick={() => addGoal({ team: 'home' })}>Gol local</button>
+      <button onClick={() => addGoal({ team: 'away' })}>Gol visitante</button>
+      <small>{renders}</small>
+    </div>
+  );
+}
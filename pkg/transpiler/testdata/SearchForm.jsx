@@ -0,0 +1,44 @@
+import { useState, useEffect } from 'react';
+import ResultItem from './ResultItem.jsx';
+
+export default function SearchForm({ endpoint, placeholder = 'Buscar...' }) {
+  const [query, setQuery] = useState('');
+  const [results, setResults] = useState([]);
+  const [focused, setFocused] = useState(false);
+
+  useEffect(() => {
+    if (!query) {
+      setResults([]);
+      return;
+    }
+    fetch(`${endpoint}?q=${encodeURIComponent(query)}`)
+      .then(res => res.json())
+      .then(data => setResults(data.items));
+  }, [query, endpoint]);
+
+  async function handleSubmit(e) {
+    e.preventDefault();
+    const res = await fetch(endpoint, { method: 'POST', body: JSON.stringify({ query }) });
+    setResults(await res.json());
+  }
+
+  return (
+    <form className={focused ? 'search focused' : 'search'} onSubmit={handleSubmit}>
+      <input
+        value={query}
+        placeholder={placeholder}
+        onChange={e => setQuery(e.target.value)}
+        onFocus={() => setFocused(true)}
+        onBlur={() => setFocused(false)}
+        onKeyDown={e => e.key === 'Escape' && setQuery('')}
+      />
+      {results.length > 0 && (
+        <ul className="results">
+          {results.map(item => (
+            <ResultItem key={item.id} item={item} />
+          ))}
+        </ul>
+      )}
+    </form>
+  );
+}
@@ -0,0 +1,19 @@
+import { forwardRef } from 'react';
+
+type SearchInputProps = {
+  value: string;
+  placeholder?: string;
+  onSearch: (query: string) => void;
+};
+
+const SearchInput = forwardRef<HTMLInputElement, SearchInputProps>((props, ref) => (
+  <input
+    ref={ref}
+    type="search"
+    value={props.value}
+    placeholder={props.placeholder ?? 'Buscar...'}
+    onChange={(e) => props.onSearch(e.target.value)}
+  />
+));
+
+export default SearchInput;
@@ -0,0 +1,14 @@
+import { useRef, useState } from 'react';
+import SearchInput from './SearchInput';
+
+export default function SearchPage() {
+  const [query, setQuery] = useState('');
+  const inputRef = useRef<HTMLInputElement>(null);
+
+  return (
+    <div>
+      <SearchInput ref={inputRef} value={query} onSearch={setQuery} />
+      <button onClick={() => inputRef.current?.focus()}>Buscar</button>
+    </div>
+  );
+}
@@ -0,0 +1,23 @@
+import React from 'react';
+
+interface StatusBannerProps {
+  status: 'idle' | 'loading' | 'error' | 'success';
+  message?: string;
+}
+
+export default function StatusBanner({ status, message }: StatusBannerProps) {
+  return (
+    <React.Fragment>
+      {status === 'loading' ? (
+        <div className="spinner" />
+      ) : status === 'error' ? (
+        <div className="banner error">
+          <strong>Error:</strong> {message}
+        </div>
+      ) : status === 'success' ? (
+        <div className="banner success">{message ?? 'Listo'}</div>
+      ) : null}
+      {message && status === 'idle' && <small>{message}</small>}
+    </React.Fragment>
+  );
+}
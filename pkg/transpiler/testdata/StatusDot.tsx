@@ -0,0 +1,20 @@
+const COLORS = {
+  online: '#2ecc71',
+  away: '#f1c40f',
+  offline: '#95a5a6',
+} as const;
+
+type Status = keyof typeof COLORS;
+
+export default function StatusDot({ status }: { status: Status }) {
+  const label = 'Estado';
+  const color = COLORS[status];
+  let clicks = 0;
+
+  return (
+    <span className="status">
+      <i className="dot" style={{ backgroundColor: color }} onClick={() => console.log(++clicks)} />
+      {label}: {status}
+    </span>
+  );
+}
@@ -0,0 +1,34 @@
+type Status = 'loading' | 'error' | 'ready';
+
+interface StatusPanelProps {
+  status: Status;
+  items: string[];
+  compact?: boolean;
+  layout: 'list' | 'grid';
+}
+
+export default function StatusPanel({ status, items, compact, layout }: StatusPanelProps) {
+  if (status === 'loading') return <p className="loading">Cargando...</p>;
+  else if (status === 'error') {
+    return <p className="error">No se pudo cargar</p>;
+  }
+
+  if (items.length === 0) {
+    if (compact) return null;
+    return <p className="empty">Sin elementos</p>;
+  }
+
+  switch (layout) {
+    case 'grid':
+      return <div className="grid">{items.length}</div>;
+  }
+
+  if (compact) return <span>{items.length}</span>;
+  else return (
+    <ul>
+      {items.map((item) => (
+        <li key={item}>{item}</li>
+      ))}
+    </ul>
+  );
+}
@@ -0,0 +1,40 @@
+import { useReducer } from 'react';
+
+interface StepperState {
+  value: number;
+  history: number[];
+}
+
+type StepperAction =
+  | { type: 'increment'; step: number }
+  | { type: 'decrement'; step: number }
+  | { type: 'reset' };
+
+function reducer(state: StepperState, action: StepperAction): StepperState {
+  switch (action.type) {
+    case 'increment':
+      return { value: state.value + action.step, history: [...state.history, state.value] };
+    case 'decrement':
+      return { value: state.value - action.step, history: [...state.history, state.value] };
+    case 'reset':
+      return init(0);
+  }
+}
+
+function init(start: number): StepperState {
+  return { value: start, history: [] };
+}
+
+export default function Stepper({ start, step = 1 }: { start: number; step?: number }) {
+  const [state, dispatch] = useReducer(reducer, start, init);
+  const canUndo = state.history.length > 0;
+
+  return (
+    <div className="stepper">
+      <button onClick={() => dispatch({ type: 'decrement', step })}>-</button>
+      <span>{state.value}</span>
+      <button onClick={() => dispatch({ type: 'increment', step })}>+</button>
+      <button disabled={!canUndo} onClick={() => dispatch({ type: 'reset' })}>Reiniciar</button>
+    </div>
+  );
+}
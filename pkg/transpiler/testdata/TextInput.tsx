@@ -0,0 +1,19 @@
+import { forwardRef } from 'react';
+
+interface TextInputProps {
+  label: string;
+  placeholder?: string;
+}
+
+const TextInput = forwardRef<HTMLInputElement, TextInputProps>(({ label, placeholder = '' }, ref) => {
+  return (
+    <label className="field">
+      <span>{label}</span>
+      <input ref={ref} placeholder={placeholder} />
+    </label>
+  );
+});
+
+TextInput.displayName = 'TextInput';
+
+export default TextInput;
@@ -0,0 +1,22 @@
+import { getContext, setContext } from 'svelte'
+
+export interface Theme {
+  mode: 'light' | 'dark';
+  accent: string;
+}
+
+export const ThemeContext = Symbol('ThemeContext');
+
+export function setThemeContext(value: Theme) {
+  return setContext(ThemeContext, value);
+}
+
+export function getThemeContext(): Theme {
+  return getContext<Theme | undefined>(ThemeContext) ?? { mode: 'light', accent: '#0070f3' };
+}
+
+export function isDark(theme: Theme) {
+  return theme.mode === 'dark';
+}
+
+export default ThemeContext;
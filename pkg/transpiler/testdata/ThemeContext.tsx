@@ -0,0 +1,14 @@
+import { createContext } from 'react';
+
+export interface Theme {
+  mode: 'light' | 'dark';
+  accent: string;
+}
+
+export const ThemeContext = createContext<Theme>({ mode: 'light', accent: '#0070f3' });
+
+export function isDark(theme: Theme) {
+  return theme.mode === 'dark';
+}
+
+export default ThemeContext;
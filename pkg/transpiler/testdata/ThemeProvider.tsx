@@ -0,0 +1,19 @@
+import { useState } from 'react';
+import { ThemeContext } from './ThemeContext';
+import ThemedButton from './ThemedButton';
+
+export default function ThemeProvider() {
+  const [mode, setMode] = useState<'light' | 'dark'>('light');
+
+  const toggle = () => {
+    setMode(mode === 'light' ? 'dark' : 'light');
+  };
+
+  return (
+    <ThemeContext.Provider value={{ mode, accent: '#0070f3' }}>
+      <main>
+        <ThemedButton onClick={toggle} label="Cambiar tema" />
+      </main>
+    </ThemeContext.Provider>
+  );
+}
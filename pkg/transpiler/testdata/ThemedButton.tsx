@@ -0,0 +1,21 @@
+import { useContext } from 'react';
+import { ThemeContext, isDark } from './ThemeContext';
+
+interface ThemedButtonProps {
+  label: string;
+  onClick: () => void;
+}
+
+export default function ThemedButton({ label, onClick }: ThemedButtonProps) {
+  const theme = useContext(ThemeContext);
+
+  return (
+    <button
+      className={isDark(theme) ? 'btn btn-dark' : 'btn'}
+      style={{ borderColor: theme.accent }}
+      onClick={onClick}
+    >
+      {label}
+    </button>
+  );
+}
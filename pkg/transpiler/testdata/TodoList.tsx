@@ -0,0 +1,45 @@
+import { useState } from 'react';
+
+type Todo = {
+  id: string;
+  text: string;
+  done: boolean;
+};
+
+export default function TodoList({ initial }: { initial: Todo[] }) {
+  const [todos, setTodos] = useState<Todo[]>(initial);
+  const [draft, setDraft] = useState('');
+
+  const addTodo = (event: React.FormEvent) => {
+    event.preventDefault();
+    if (!draft.trim()) return;
+    setTodos(prev => [...prev, { id: crypto.randomUUID(), text: draft, done: false }]);
+    setDraft('');
+  };
+
+  const toggle = (id: string) => {
+    setTodos(todos.map(t => (t.id === id ? { ...t, done: !t.done } : t)));
+  };
+
+  return (
+    <>
+      {/* Formulario para nuevas tareas */}
+      <form onSubmit={addTodo}>
+        <input value={draft} onChange={e => setDraft(e.target.value)} placeholder="Nueva tarea" />
+        <button type="submit">Añadir</button>
+      </form>
+      {todos.length === 0 ? (
+        <p className="empty">No hay tareas</p>
+      ) : (
+        <ul>
+          {todos.map((todo, index) => (
+            <li key={todo.id} className={todo.done ? 'done' : ''}>
+              <input type="checkbox" checked={todo.done} onChange={() => toggle(todo.id)} />
+              {index + 1}. {todo.text}
+            </li>
+          ))}
+        </ul>
+      )}
+    </>
+  );
+}
@@ -0,0 +1,25 @@
+import type { ReactNode } from 'react';
+
+type IconButtonProps = {
+  icon?: ReactNode;
+  label: string;
+  onClick: () => void;
+};
+
+export function IconButton({ icon, label, onClick }: IconButtonProps) {
+  return (
+    <button type="button" onClick={onClick}>
+      {icon && <span className="icon">{icon}</span>}
+      {label}
+    </button>
+  );
+}
+
+export default function Toolbar({ onSave, onUndo }: { onSave: () => void; onUndo: () => void }) {
+  return (
+    <div role="toolbar">
+      <IconButton icon={<svg className="icon-save" />} label="Guardar" onClick={onSave} />
+      <IconButton label="Deshacer" onClick={onUndo} />
+    </div>
+  );
+}
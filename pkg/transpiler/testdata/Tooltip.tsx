@@ -0,0 +1,27 @@
+import React, { useEffect, useLayoutEffect, useRef, useState } from 'react';
+
+export default function Tooltip({ text, anchor }: { text: string; anchor: DOMRect }) {
+  const tipRef = useRef<HTMLDivElement>(null);
+  const [height, setHeight] = useState(0);
+
+  useLayoutEffect(() => {
+    setHeight(tipRef.current.getBoundingClientRect().height);
+  }, [text]);
+
+  React.useInsertionEffect(() => {
+    const style = document.createElement('style');
+    style.textContent = '.tooltip { position: fixed; }';
+    document.head.appendChild(style);
+    return () => style.remove();
+  }, []);
+
+  useEffect(() => {
+    console.debug('tooltip render', text, height);
+  });
+
+  return (
+    <div ref={tipRef} className="tooltip" style={{ top: anchor.top - height, left: anchor.left }}>
+      {text}
+    </div>
+  );
+}
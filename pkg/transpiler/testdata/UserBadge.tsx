@@ -0,0 +1,20 @@
+import React from 'react';
+
+interface UserBadgeProps {
+  name: string;
+  email?: string;
+  children?: React.ReactNode;
+}
+
+const UserBadge: React.FC<UserBadgeProps> = (props) => {
+  const initials = props.name.slice(0, 2).toUpperCase();
+
+  return (
+    <span className="user-badge" title={props.email}>
+      <b>{initials}</b> {props.name}
+      {props.children}
+    </span>
+  );
+};
+
+export default UserBadge;
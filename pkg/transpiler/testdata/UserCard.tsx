@@ -0,0 +1,32 @@
+import React, { useState } from 'react';
+import Avatar from './Avatar.tsx';
+
+interface User {
+  id: number;
+  name: string;
+  email: string;
+}
+
+interface UserCardProps {
+  user: User;
+  showEmail?: boolean;
+  onSelect: (id: number) => void;
+}
+
+export function UserCard({ user, showEmail = true, onSelect }: UserCardProps) {
+  const [expanded, setExpanded] = useState(false);
+
+  const handleClick = () => {
+    setExpanded(!expanded);
+    onSelect(user.id);
+  };
+
+  return (
+    <div className="user-card" onClick={handleClick}>
+      <Avatar name={user.name} />
+      <h3>{user.name}</h3>
+      {showEmail && <p className="email">{user.email}</p>}
+      {expanded ? <span>Ocultar</span> : <span>Ver más</span>}
+    </div>
+  );
+}
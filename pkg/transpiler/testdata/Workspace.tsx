@@ -0,0 +1,25 @@
+import { createContext, useState } from 'react';
+import Sidebar from './Sidebar';
+import Editor from './Editor';
+
+const ThemeContext = createContext('light');
+const UserContext = createContext<string | null>(null);
+
+export default function Workspace({ user }: { user?: string }) {
+  const [dark, setDark] = useState(false);
+
+  return (
+    <ThemeContext.Provider value={dark ? 'dark' : 'light'}>
+      <button onClick={() => setDark(!dark)}>Tema</button>
+      <Sidebar />
+      <ThemeContext.Provider value="light">
+        <Editor />
+      </ThemeContext.Provider>
+      {user && (
+        <UserContext.Provider value={user}>
+          <p>{user}</p>
+        </UserContext.Provider>
+      )}
+    </ThemeContext.Provider>
+  );
+}
@@ -0,0 +1,38 @@
+import type { Product } from '../types'
+
+export interface CartItem {
+  product: Product;
+  quantity: number;
+}
+
+const STORAGE_KEY = 'cart';
+
+export function useCart(initialItems: CartItem[] = []) {
+  // States
+  let items = $state<CartItem[]>(initialItems);
+
+  // Derived
+  let total = $derived(items.reduce((sum, item) => sum + item.product.price * item.quantity, 0));
+
+  // Effects
+  $effect(() => {
+    localStorage.setItem(STORAGE_KEY, JSON.stringify(items));
+  });
+
+  // Functions
+  function add(product: Product) {
+    items = [...items, { product, quantity: 1 }];
+  }
+
+  function clear() {
+    items = [];
+  }
+
+  return {
+    get items() { return items; },
+    get total() { return total; },
+    add,
+    clear,
+    get count() { return items.length; },
+  };
+}
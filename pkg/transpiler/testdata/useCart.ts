@@ -0,0 +1,32 @@
+import { useState, useMemo, useEffect, useCallback } from 'react';
+import type { Product } from '../types';
+
+export interface CartItem {
+  product: Product;
+  quantity: number;
+}
+
+const STORAGE_KEY = 'cart';
+
+export function useCart(initialItems: CartItem[] = []) {
+  const [items, setItems] = useState<CartItem[]>(initialItems);
+
+  const total = useMemo(
+    () => items.reduce((sum, item) => sum + item.product.price * item.quantity, 0),
+    [items]
+  );
+
+  useEffect(() => {
+    localStorage.setItem(STORAGE_KEY, JSON.stringify(items));
+  }, [items]);
+
+  const add = useCallback((product: Product) => {
+    setItems((prev) => [...prev, { product, quantity: 1 }]);
+  }, []);
+
+  function clear() {
+    setItems([]);
+  }
+
+  return { items, total, add, clear, count: items.length };
+}
@@ -0,0 +1,19 @@
+export function useCounter(initial: number): { 0: number; 1: () => void; 2: () => void } {
+  // States
+  let count = $state(initial);
+
+  // Functions
+  function increment() {
+    count = count + 1;
+  }
+
+  function reset() {
+    count = initial;
+  }
+
+  return {
+    get 0() { return count; },
+    1: increment,
+    2: reset,
+  };
+}
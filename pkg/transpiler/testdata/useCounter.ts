@@ -0,0 +1,10 @@
+import { useState } from 'react';
+
+export function useCounter(initial: number): [count: number, increment: () => void, reset: () => void] {
+  const [count, setCount] = useState(initial);
+
+  const increment = () => setCount((c) => c + 1);
+  const reset = () => setCount(initial);
+
+  return [count, increment, reset];
+}
@@ -0,0 +1,17 @@
+function useToggle(initial = false) {
+  // States
+  let on = $state(initial);
+
+  // Functions
+  function toggle() {
+    on = !on;
+  }
+
+  return {
+    get 0() { return on; },
+    1: toggle,
+    2: (value: any) => (on = value),
+  };
+}
+
+export default useToggle;
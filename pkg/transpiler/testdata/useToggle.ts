@@ -0,0 +1,11 @@
+import { useState } from 'react';
+
+function useToggle(initial = false) {
+  const [on, setOn] = useState(initial);
+
+  const toggle = () => setOn(!on);
+
+  return [on, toggle, setOn] as const;
+}
+
+export default useToggle;
@@ -1,38 +1,121 @@
 package transpiler
 
 import (
-	"fmt"
+	"errors"
+	"sort"
 )
 
 // Transpilador principal
-type Transpiler struct{}
+type Transpiler struct {
+	todoMarkers bool
+	onMount     bool
+}
+
+// Opción de configuración del transpilador
+type Option func(*Transpiler)
 
-func NewTranspiler() *Transpiler {
-	return &Transpiler{}
+// Emitir un comentario // TODO(r2s): en el script por cada construcción que
+// no se pudo convertir, con el código original comentado
+func WithTodoMarkers() Option {
+	return func(t *Transpiler) {
+		t.todoMarkers = true
+	}
 }
 
-// Función principal de transpilación
-func (t *Transpiler) TranspileComponent(reactCode string) (string, error) {
-	// Separar el código JSX del código JavaScript/TypeScript
-	jsCode, jsxContent, err := t.separateJSXFromCode(reactCode)
-	if err != nil {
-		return "", fmt.Errorf("error separando JSX del código: %v", err)
+// Convertir los efectos con lista de dependencias vacía en onMount, en lugar
+// de $effect
+func WithOnMount() Option {
+	return func(t *Transpiler) {
+		t.onMount = true
 	}
+}
 
-	// Parsear el código JavaScript/TypeScript
-	component, err := t.parseReactCode(jsCode)
+func NewTranspiler(opts ...Option) *Transpiler {
+	t := &Transpiler{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Función principal de transpilación. filename solo se usa para ubicar los
+// diagnósticos. Si el código no se puede analizar se devuelve el Result con
+// el diagnóstico del error y ese mismo diagnóstico como error.
+func (t *Transpiler) TranspileComponent(filename, reactCode string) (*Result, error) {
+	// Analizar el código TypeScript + JSX
+	file, err := parseFile(reactCode)
 	if err != nil {
-		return "", fmt.Errorf("error parseando código React: %v", err)
+		var se *syntaxError
+		if !errors.As(err, &se) {
+			return nil, err
+		}
+		diag := newDiagnostic(filename, reactCode, se.pos, SeverityError, CodeSyntaxError, se.msg)
+		return &Result{Diagnostics: []Diagnostic{diag}}, diag
+	}
+
+	// Localizar el componente principal y el resto de componentes del archivo
+	c := newConverter(filename, reactCode, file)
+	c.main = c.findComponent()
+	c.decl = c.main
+	if c.main != nil {
+		c.findSiblings(c.main)
+	}
+
+	component, markup := t.extract(c)
+	result := &Result{Diagnostics: c.diagnostics}
+	if c.main != nil {
+		result.Component = c.main.name
+	}
+
+	// Cada componente secundario se convierte con su propio estado e importa
+	// del principal el código del módulo; los pequeños se añaden como
+	// snippets al markup del principal
+	for _, s := range c.siblingList() {
+		sc := newConverter(filename, reactCode, file)
+		sc.main, sc.decl, sc.siblings, sc.shared = c.main, s.decl, c.siblings, c.moduleNames
+		siblingComponent, siblingMarkup := t.extract(sc)
+		if s.snippet {
+			markup += "\n\n" + snippetBlock(s.decl.name, siblingComponent.Props, siblingMarkup)
+		} else {
+			code, _ := t.generate(sc, siblingComponent, siblingMarkup)
+			result.Files = append(result.Files, File{Name: s.decl.name + ".svelte", Code: code})
+			// El código del módulo que importa pasa al <script module> del principal
+			component.Module = component.Module || sc.importsShared
+		}
+		result.Diagnostics = append(result.Diagnostics, sc.diagnostics...)
 	}
 
+	result.Code, result.Extension = t.generate(c, component, markup)
+	sortDiagnostics(result.Diagnostics)
+	return result, nil
+}
+
+// Extraer el componente del árbol sintáctico y convertir su JSX
+func (t *Transpiler) extract(c *converter) (*ReactComponent, string) {
+	component := c.parseReactCode()
+
 	// Procesar el JSX
-	component.JSXContent = jsxContent
-	processedJSX, err := t.processJSX(jsxContent)
-	if err != nil {
-		return "", fmt.Errorf("error procesando JSX: %v", err)
+	processedJSX := c.processJSX()
+
+	// Construcciones pendientes, en orden de aparición
+	component.Todos = c.todos
+	sort.SliceStable(component.Todos, func(i, j int) bool {
+		return component.Todos[i].Line < component.Todos[j].Line
+	})
+	return component, processedJSX
+}
+
+// Generar el código del componente y la extensión del archivo
+func (t *Transpiler) generate(c *converter, component *ReactComponent, processedJSX string) (string, string) {
+	// Un archivo sin componente que declara contextos es un módulo .ts, y uno
+	// con hooks, un módulo .svelte.ts que puede usar runes
+	if c.decl == nil && len(component.Hooks) > 0 {
+		return t.generateModule(component), ".svelte.ts"
+	}
+	if c.decl == nil && len(component.Contexts) > 0 {
+		return t.generateModule(component), ".ts"
 	}
 
 	// Generar código Svelte
-	svelteCode := t.generateSvelteCode(component, processedJSX)
-	return svelteCode, nil
+	return t.generateSvelteCode(component, processedJSX), ".svelte"
 }
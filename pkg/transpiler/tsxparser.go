@@ -0,0 +1,1940 @@
+package transpiler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Error de sintaxis con la posición (offset en bytes) donde se produjo
+type syntaxError struct {
+	pos int
+	msg string
+}
+
+func (e *syntaxError) Error() string {
+	return e.msg
+}
+
+// Parser descendente recursivo para TypeScript + JSX
+type parser struct {
+	src   string
+	limit int
+	tok   token
+	prev  token
+}
+
+type parserState struct {
+	tok  token
+	prev token
+}
+
+// Nombres de hooks: useState, useEffect, useCustomThing...
+var hookNameRegex = regexp.MustCompile(`^use[A-Z0-9]\w*$`)
+
+// Analizar un archivo TSX completo
+func parseFile(src string) (prog *program, err error) {
+	p := &parser{src: src, limit: len(src)}
+	defer p.recover(&err)
+
+	p.tok = p.scan(0, true)
+	prog = &program{span: span{0, len(src)}}
+	for p.tok.kind != tokEOF {
+		prog.body = append(prog.body, p.parseStatement())
+	}
+	return prog, nil
+}
+
+func (p *parser) recover(err *error) {
+	if r := recover(); r != nil {
+		if se, ok := r.(*syntaxError); ok {
+			*err = se
+			return
+		}
+		panic(r)
+	}
+}
+
+func (p *parser) fail(pos int, format string, args ...any) {
+	panic(&syntaxError{pos: pos, msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) failHere(format string, args ...any) {
+	if p.tok.kind == tokEOF {
+		p.fail(p.tok.pos, "fin de archivo inesperado: "+format, args...)
+	}
+	p.fail(p.tok.pos, format+" (se encontró %q)", append(args, p.tok.val)...)
+}
+
+func (p *parser) save() parserState {
+	return parserState{p.tok, p.prev}
+}
+
+func (p *parser) restore(s parserState) {
+	p.tok, p.prev = s.tok, s.prev
+}
+
+// Ejecutar fn de forma especulativa; si falla se restaura el estado
+func (p *parser) try(fn func()) (ok bool) {
+	state := p.save()
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isSyntax := r.(*syntaxError); !isSyntax {
+				panic(r)
+			}
+			p.restore(state)
+			ok = false
+		}
+	}()
+	fn()
+	return true
+}
+
+func (p *parser) next() {
+	regexOK := allowsRegex(p.tok)
+	p.prev = p.tok
+	p.tok = p.scan(p.tok.end, regexOK)
+}
+
+func (p *parser) nextJSXTag() {
+	p.prev = p.tok
+	p.tok = p.scanJSXTag(p.tok.end)
+}
+
+func (p *parser) nextJSXChild() {
+	p.prev = p.tok
+	p.tok = p.scanJSXChild(p.tok.end)
+}
+
+func (p *parser) peek() token {
+	return p.scan(p.tok.end, allowsRegex(p.tok))
+}
+
+func (p *parser) at(val string) bool {
+	return (p.tok.kind == tokPunct || p.tok.kind == tokIdent) && p.tok.val == val
+}
+
+func (p *parser) eat(val string) bool {
+	if p.at(val) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(val string) {
+	if !p.at(val) {
+		p.failHere("se esperaba %q", val)
+	}
+	p.next()
+}
+
+func (p *parser) expectIdent() string {
+	if p.tok.kind != tokIdent {
+		p.failHere("se esperaba un identificador")
+	}
+	name := p.tok.val
+	p.next()
+	return name
+}
+
+// Fin de sentencia con inserción automática de punto y coma
+func (p *parser) semicolon() {
+	if p.eat(";") {
+		return
+	}
+	if p.at("}") || p.tok.kind == tokEOF || p.tok.nl {
+		return
+	}
+	p.failHere("se esperaba ';'")
+}
+
+// Fin del último token consumido
+func (p *parser) lastEnd() int {
+	return p.prev.end
+}
+
+func (p *parser) text(start, end int) string {
+	return p.src[start:end]
+}
+
+// ---- Sentencias ----
+
+func (p *parser) parseStatement() node {
+	start := p.tok.pos
+
+	if p.tok.kind == tokPunct {
+		switch p.tok.val {
+		case "{":
+			return p.parseBlock()
+		case ";":
+			p.next()
+			return &emptyStmt{span{start, p.lastEnd()}}
+		case "@":
+			// Decoradores: se ignoran y se analiza la declaración
+			p.next()
+			p.parseLeftHandSide()
+			return p.parseStatement()
+		}
+	}
+
+	if p.tok.kind == tokIdent {
+		next := p.peek()
+		sameLine := !next.nl
+		switch p.tok.val {
+		case "import":
+			if next.val != "(" && next.val != "." {
+				return p.parseImport()
+			}
+		case "export":
+			return p.parseExport()
+		case "const":
+			if next.val == "enum" {
+				p.next()
+				return p.parseEnum(start)
+			}
+			return p.parseVarStatement()
+		case "let", "var":
+			if next.kind == tokIdent || next.val == "[" || next.val == "{" {
+				return p.parseVarStatement()
+			}
+		case "using":
+			if next.kind == tokIdent && sameLine && next.val != "in" && next.val != "instanceof" {
+				return p.parseVarStatement()
+			}
+		case "await":
+			if next.val == "using" && sameLine {
+				state := p.save()
+				p.next()
+				after := p.peek()
+				p.restore(state)
+				if after.kind == tokIdent && !after.nl {
+					return p.parseVarStatement()
+				}
+			}
+		case "function":
+			return p.parseFuncDecl(start, false)
+		case "async":
+			if next.val == "function" && sameLine {
+				p.next()
+				return p.parseFuncDecl(start, true)
+			}
+		case "class":
+			return p.parseClass(start)
+		case "abstract":
+			if next.val == "class" && sameLine {
+				p.next()
+				return p.parseClass(start)
+			}
+		case "interface":
+			if next.kind == tokIdent && sameLine {
+				return p.parseInterface(start)
+			}
+		case "type":
+			if next.kind == tokIdent && sameLine {
+				return p.parseTypeAlias(start)
+			}
+		case "enum":
+			if next.kind == tokIdent && sameLine {
+				return p.parseEnum(start)
+			}
+		case "declare":
+			if next.kind == tokIdent && sameLine {
+				p.next()
+				if p.at("global") {
+					return p.parseNamespace(start)
+				}
+				// Los namespaces declarados se conservan para avisar de ellos
+				if ns, ok := p.parseStatement().(*namespaceDecl); ok {
+					ns.start = start
+					return ns
+				}
+				return &emptyStmt{span{start, p.lastEnd()}}
+			}
+		case "namespace", "module":
+			if (next.kind == tokIdent || next.kind == tokString) && sameLine {
+				return p.parseNamespace(start)
+			}
+		case "return":
+			p.next()
+			var arg node
+			if !p.at(";") && !p.at("}") && p.tok.kind != tokEOF && !p.tok.nl {
+				arg = p.parseExpression()
+			}
+			p.semicolon()
+			return &returnStmt{span{start, p.lastEnd()}, arg}
+		case "if":
+			return p.parseIf()
+		case "for":
+			return p.parseFor()
+		case "while":
+			p.next()
+			p.expect("(")
+			test := p.parseExpression()
+			p.expect(")")
+			body := p.parseStatement()
+			return &whileStmt{span{start, p.lastEnd()}, test, body}
+		case "do":
+			p.next()
+			body := p.parseStatement()
+			if !p.at("while") {
+				p.failHere("se esperaba 'while'")
+			}
+			p.next()
+			p.expect("(")
+			test := p.parseExpression()
+			p.expect(")")
+			p.eat(";")
+			return &doWhileStmt{span{start, p.lastEnd()}, body, test}
+		case "switch":
+			return p.parseSwitch()
+		case "try":
+			return p.parseTry()
+		case "throw":
+			p.next()
+			arg := p.parseExpression()
+			p.semicolon()
+			return &throwStmt{span{start, p.lastEnd()}, arg}
+		case "break", "continue":
+			keyword := p.tok.val
+			p.next()
+			label := ""
+			if p.tok.kind == tokIdent && !p.tok.nl {
+				label = p.expectIdent()
+			}
+			p.semicolon()
+			return &jumpStmt{span{start, p.lastEnd()}, keyword, label}
+		default:
+			if next.val == ":" {
+				label := p.expectIdent()
+				p.next()
+				body := p.parseStatement()
+				return &labeledStmt{span{start, p.lastEnd()}, label, body}
+			}
+		}
+	}
+
+	expr := p.parseExpression()
+	p.semicolon()
+	return &exprStmt{span{start, p.lastEnd()}, expr}
+}
+
+func (p *parser) parseBlock() *block {
+	start := p.tok.pos
+	p.expect("{")
+	b := &block{}
+	for !p.at("}") {
+		if p.tok.kind == tokEOF {
+			p.fail(start, "bloque sin cerrar")
+		}
+		b.body = append(b.body, p.parseStatement())
+	}
+	p.next()
+	b.span = span{start, p.lastEnd()}
+	return b
+}
+
+func (p *parser) parseImport() node {
+	start := p.tok.pos
+	p.next()
+	decl := &importDecl{}
+
+	if p.at("type") {
+		next := p.peek()
+		if next.val == "{" || next.val == "*" || (next.kind == tokIdent && next.val != "from") {
+			decl.typeOnly = true
+			p.next()
+		}
+	}
+
+	if p.tok.kind != tokString {
+		if p.tok.kind == tokIdent {
+			decl.defaultName = p.expectIdent()
+			p.eat(",")
+		}
+		if p.eat("*") {
+			if !p.at("as") {
+				p.failHere("se esperaba 'as'")
+			}
+			p.next()
+			decl.namespace = p.expectIdent()
+		} else if p.eat("{") {
+			for !p.at("}") {
+				spec := importSpec{}
+				if p.at("type") && p.peek().kind == tokIdent {
+					spec.typeOnly = true
+					p.next()
+				}
+				spec.imported = p.parsePropertyName()
+				spec.local = spec.imported
+				if p.at("as") {
+					p.next()
+					spec.local = p.expectIdent()
+				}
+				decl.named = append(decl.named, spec)
+				if !p.eat(",") {
+					break
+				}
+			}
+			p.expect("}")
+		}
+		if !p.at("from") {
+			p.failHere("se esperaba 'from'")
+		}
+		p.next()
+	}
+
+	if p.tok.kind != tokString {
+		p.failHere("se esperaba la ruta del módulo")
+	}
+	decl.source = unquote(p.tok.val)
+	p.next()
+	if p.at("with") || p.at("assert") {
+		p.next()
+		p.skipBalanced("{", "}")
+	}
+	p.semicolon()
+	decl.span = span{start, p.lastEnd()}
+	return decl
+}
+
+func (p *parser) parseExport() node {
+	start := p.tok.pos
+	p.next()
+	decl := &exportDecl{}
+
+	switch {
+	case p.at("default"):
+		decl.isDefault = true
+		p.next()
+		next := p.peek()
+		switch {
+		case p.at("function"):
+			decl.decl = p.parseFuncDecl(p.tok.pos, false)
+		case p.at("async") && next.val == "function" && !next.nl:
+			declStart := p.tok.pos
+			p.next()
+			decl.decl = p.parseFuncDecl(declStart, true)
+		case p.at("class"), p.at("abstract") && next.val == "class":
+			decl.decl = p.parseClass(p.tok.pos)
+		case p.at("interface") && next.kind == tokIdent:
+			decl.decl = p.parseInterface(p.tok.pos)
+		default:
+			decl.expr = p.parseAssign()
+			p.semicolon()
+		}
+	case p.at("*"):
+		p.next()
+		if p.at("as") {
+			p.next()
+			decl.names = append(decl.names, p.parsePropertyName())
+		}
+		decl.source = p.parseFromClause()
+		p.semicolon()
+	case p.at("{") || (p.at("type") && p.peek().val == "{"):
+		if p.at("type") {
+			p.next()
+		}
+		p.next()
+		for !p.at("}") {
+			if p.at("type") && p.peek().kind == tokIdent {
+				p.next()
+			}
+			name := p.parsePropertyName()
+			if p.at("as") {
+				p.next()
+				name = p.parsePropertyName()
+			}
+			decl.names = append(decl.names, name)
+			if !p.eat(",") {
+				break
+			}
+		}
+		p.expect("}")
+		if p.at("from") {
+			decl.source = p.parseFromClause()
+		}
+		p.semicolon()
+	case p.at("="):
+		p.next()
+		decl.expr = p.parseExpression()
+		p.semicolon()
+	default:
+		decl.decl = p.parseStatement()
+	}
+
+	decl.span = span{start, p.lastEnd()}
+	return decl
+}
+
+func (p *parser) parseFromClause() string {
+	if !p.at("from") {
+		p.failHere("se esperaba 'from'")
+	}
+	p.next()
+	if p.tok.kind != tokString {
+		p.failHere("se esperaba la ruta del módulo")
+	}
+	source := unquote(p.tok.val)
+	p.next()
+	return source
+}
+
+func (p *parser) parseVarStatement() *varDecl {
+	decl := p.parseVarDecl()
+	p.semicolon()
+	decl.end = p.lastEnd()
+	return decl
+}
+
+// Declaración de variables sin el punto y coma final (usada también en for)
+func (p *parser) parseVarDecl() *varDecl {
+	start := p.tok.pos
+	decl := &varDecl{kind: p.tok.val}
+	if p.eat("await") {
+		decl.kind = "await using"
+	}
+	p.next()
+	for {
+		d := &declarator{}
+		dStart := p.tok.pos
+		d.target = p.parseBindingTarget()
+		p.eat("!")
+		if p.eat(":") {
+			d.typ = p.parseType()
+		}
+		if p.eat("=") {
+			d.init = p.parseAssign()
+		}
+		d.span = span{dStart, p.lastEnd()}
+		decl.decls = append(decl.decls, d)
+		if !p.eat(",") {
+			break
+		}
+	}
+	decl.span = span{start, p.lastEnd()}
+	return decl
+}
+
+func (p *parser) parseFuncDecl(start int, async bool) *funcDecl {
+	fn := p.parseFunction(start, async)
+	return &funcDecl{span{start, p.lastEnd()}, fn.name, fn}
+}
+
+// Analizar "function [*] [nombre] <T>(params): Tipo { ... }" a partir de la palabra function
+func (p *parser) parseFunction(start int, async bool) *function {
+	p.expect("function")
+	fn := &function{async: async}
+	if p.eat("*") {
+		fn.generator = true
+	}
+	if p.tok.kind == tokIdent && !p.at("(") {
+		fn.name = p.expectIdent()
+	}
+	p.parseSignature(fn)
+	if p.at("{") {
+		fn.body = p.parseBlock()
+	} else {
+		// Sobrecarga de TypeScript sin cuerpo
+		p.semicolon()
+	}
+	fn.span = span{start, p.lastEnd()}
+	return fn
+}
+
+// Parámetros de tipo, parámetros y tipo de retorno de una función
+func (p *parser) parseSignature(fn *function) {
+	if p.at("<") {
+		fn.typeParams = p.parseTypeParams()
+	}
+	fn.params = p.parseParams()
+	if p.eat(":") {
+		fn.returnType = p.parseReturnType()
+	}
+}
+
+func (p *parser) parseParams() []*param {
+	var params []*param
+	p.expect("(")
+	for !p.at(")") {
+		start := p.tok.pos
+		for p.at("@") {
+			p.next()
+			p.parseLeftHandSide()
+		}
+		for (p.at("public") || p.at("private") || p.at("protected") || p.at("readonly") || p.at("override")) &&
+			p.peek().kind == tokIdent {
+			p.next()
+		}
+		prm := &param{}
+		if p.eat("...") {
+			prm.rest = true
+		}
+		prm.target = p.parseBindingTarget()
+		if p.eat("?") {
+			prm.optional = true
+		}
+		if p.eat(":") {
+			prm.typ = p.parseType()
+		}
+		if p.eat("=") {
+			prm.def = p.parseAssign()
+		}
+		prm.span = span{start, p.lastEnd()}
+		params = append(params, prm)
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect(")")
+	return params
+}
+
+func (p *parser) parseClass(start int) node {
+	p.eat("abstract")
+	p.expect("class")
+	name := ""
+	if p.tok.kind == tokIdent && !p.at("extends") && !p.at("implements") {
+		name = p.expectIdent()
+	}
+	for !p.at("{") {
+		if p.tok.kind == tokEOF {
+			p.failHere("se esperaba el cuerpo de la clase")
+		}
+		if p.at("(") || p.at("[") {
+			p.skipBalanced(p.tok.val, closerOf(p.tok.val))
+			continue
+		}
+		// extends React.Component<{ a: number }>: los argumentos de tipo
+		// pueden contener llaves
+		if p.at("<") {
+			p.parseTypeArgs()
+			continue
+		}
+		p.next()
+	}
+	p.skipBalanced("{", "}")
+	return &classDecl{span{start, p.lastEnd()}, name}
+}
+
+func (p *parser) parseInterface(start int) node {
+	p.expect("interface")
+	decl := &interfaceDecl{name: p.expectIdent()}
+	if p.at("<") {
+		decl.typeParams = p.parseTypeParams()
+	}
+	if p.at("extends") {
+		p.next()
+		extStart := p.tok.pos
+		for {
+			p.parseType()
+			if !p.eat(",") {
+				break
+			}
+		}
+		decl.extends = p.text(extStart, p.lastEnd())
+	}
+	decl.members = p.parseTypeMembers()
+	decl.span = span{start, p.lastEnd()}
+	return decl
+}
+
+func (p *parser) parseTypeAlias(start int) node {
+	p.expect("type")
+	decl := &typeAlias{name: p.expectIdent()}
+	if p.at("<") {
+		decl.typeParams = p.parseTypeParams()
+	}
+	p.expect("=")
+	if p.at("{") {
+		typStart := p.tok.pos
+		state := p.save()
+		members := p.parseTypeMembers()
+		if p.at("|") || p.at("&") || p.at("[") {
+			// El objeto es solo parte de un tipo compuesto; en una
+			// intersección sus miembros siguen siendo válidos
+			intersection := p.at("&")
+			p.restore(state)
+			decl.typ = p.parseType()
+			if intersection {
+				decl.members = members
+			}
+		} else {
+			decl.members = members
+			decl.typ = p.text(typStart, p.lastEnd())
+		}
+	} else {
+		decl.typ = p.parseType()
+	}
+	p.semicolon()
+	decl.span = span{start, p.lastEnd()}
+	return decl
+}
+
+func (p *parser) parseEnum(start int) node {
+	p.expect("enum")
+	name := p.expectIdent()
+	p.skipBalanced("{", "}")
+	return &enumDecl{span{start, p.lastEnd()}, name}
+}
+
+// namespace A.B { ... }, module 'x' { ... } o global { ... }; el contenido
+// no se analiza
+func (p *parser) parseNamespace(start int) node {
+	name := p.tok.val
+	p.next()
+	if name != "global" {
+		nameStart := p.tok.pos
+		if p.tok.kind == tokString {
+			p.next()
+		} else {
+			p.expectIdent()
+			for p.eat(".") {
+				p.expectIdent()
+			}
+		}
+		name = p.text(nameStart, p.lastEnd())
+	}
+	// declare module 'x'; no tiene cuerpo
+	if p.at("{") {
+		p.skipBalanced("{", "}")
+	} else {
+		p.semicolon()
+	}
+	return &namespaceDecl{span{start, p.lastEnd()}, name}
+}
+
+func (p *parser) parseIf() node {
+	start := p.tok.pos
+	p.next()
+	p.expect("(")
+	test := p.parseExpression()
+	p.expect(")")
+	cons := p.parseStatement()
+	var alt node
+	if p.at("else") {
+		p.next()
+		alt = p.parseStatement()
+	}
+	return &ifStmt{span{start, p.lastEnd()}, test, cons, alt}
+}
+
+func (p *parser) parseFor() node {
+	start := p.tok.pos
+	p.next()
+	p.eat("await")
+	p.expect("(")
+
+	var init node
+	if !p.at(";") {
+		if p.at("const") || p.at("let") || p.at("var") {
+			init = p.parseVarDecl()
+		} else {
+			init = p.parseExpressionNoIn()
+		}
+	}
+
+	if p.at("of") || p.at("in") {
+		of := p.at("of")
+		p.next()
+		right := p.parseExpression()
+		p.expect(")")
+		body := p.parseStatement()
+		return &forInStmt{span{start, p.lastEnd()}, init, right, body, of}
+	}
+
+	stmt := &forStmt{init: init}
+	p.expect(";")
+	if !p.at(";") {
+		stmt.test = p.parseExpression()
+	}
+	p.expect(";")
+	if !p.at(")") {
+		stmt.update = p.parseExpression()
+	}
+	p.expect(")")
+	stmt.body = p.parseStatement()
+	stmt.span = span{start, p.lastEnd()}
+	return stmt
+}
+
+func (p *parser) parseSwitch() node {
+	start := p.tok.pos
+	p.next()
+	p.expect("(")
+	stmt := &switchStmt{disc: p.parseExpression()}
+	p.expect(")")
+	p.expect("{")
+	for !p.at("}") {
+		caseStart := p.tok.pos
+		c := &switchCase{}
+		if p.at("case") {
+			p.next()
+			c.test = p.parseExpression()
+		} else if p.at("default") {
+			p.next()
+		} else {
+			p.failHere("se esperaba 'case' o 'default'")
+		}
+		p.expect(":")
+		for !p.at("case") && !p.at("default") && !p.at("}") {
+			if p.tok.kind == tokEOF {
+				p.fail(start, "switch sin cerrar")
+			}
+			c.body = append(c.body, p.parseStatement())
+		}
+		c.span = span{caseStart, p.lastEnd()}
+		stmt.cases = append(stmt.cases, c)
+	}
+	p.next()
+	stmt.span = span{start, p.lastEnd()}
+	return stmt
+}
+
+func (p *parser) parseTry() node {
+	start := p.tok.pos
+	p.next()
+	stmt := &tryStmt{block: p.parseBlock()}
+	if p.at("catch") {
+		p.next()
+		if p.eat("(") {
+			stmt.param = p.parseBindingTarget()
+			if p.eat(":") {
+				p.parseType()
+			}
+			p.expect(")")
+		}
+		stmt.handler = p.parseBlock()
+	}
+	if p.at("finally") {
+		p.next()
+		stmt.finalizer = p.parseBlock()
+	}
+	stmt.span = span{start, p.lastEnd()}
+	return stmt
+}
+
+// ---- Patrones ----
+
+func (p *parser) parseBindingTarget() node {
+	start := p.tok.pos
+	switch {
+	case p.at("{"):
+		p.next()
+		pat := &objectPattern{}
+		for !p.at("}") {
+			propStart := p.tok.pos
+			prop := &bindingProp{}
+			if p.eat("...") {
+				prop.rest = true
+				prop.value = p.parseBindingTarget()
+			} else {
+				if p.at("[") {
+					p.next()
+					prop.computed = true
+					prop.key = p.parseAssign()
+					p.expect("]")
+				} else {
+					keyStart := p.tok.pos
+					name := p.parsePropertyName()
+					prop.key = &ident{span{keyStart, p.lastEnd()}, name}
+				}
+				if p.eat(":") {
+					prop.value = p.parseBindingTarget()
+				} else {
+					prop.shorthand = true
+					prop.value = prop.key
+				}
+				if p.eat("=") {
+					prop.def = p.parseAssign()
+				}
+			}
+			prop.span = span{propStart, p.lastEnd()}
+			pat.props = append(pat.props, prop)
+			if !p.eat(",") {
+				break
+			}
+		}
+		p.expect("}")
+		pat.span = span{start, p.lastEnd()}
+		return pat
+	case p.at("["):
+		p.next()
+		pat := &arrayPattern{}
+		for !p.at("]") {
+			if p.at(",") {
+				p.next()
+				pat.elems = append(pat.elems, nil)
+				continue
+			}
+			elemStart := p.tok.pos
+			elem := &bindingElem{}
+			if p.eat("...") {
+				elem.rest = true
+			}
+			elem.target = p.parseBindingTarget()
+			if p.eat("=") {
+				elem.def = p.parseAssign()
+			}
+			elem.span = span{elemStart, p.lastEnd()}
+			pat.elems = append(pat.elems, elem)
+			if !p.eat(",") {
+				break
+			}
+		}
+		p.expect("]")
+		pat.span = span{start, p.lastEnd()}
+		return pat
+	}
+	name := p.expectIdent()
+	return &ident{span{start, p.lastEnd()}, name}
+}
+
+// Nombre de propiedad: identificador (incluidas palabras reservadas), cadena o número
+func (p *parser) parsePropertyName() string {
+	switch p.tok.kind {
+	case tokIdent, tokNumber:
+		name := p.tok.val
+		p.next()
+		return name
+	case tokString:
+		name := unquote(p.tok.val)
+		p.next()
+		return name
+	}
+	p.failHere("se esperaba un nombre de propiedad")
+	return ""
+}
+
+// ---- Tipos (se conservan como texto) ----
+
+// Analizar un tipo y devolver su texto original
+func (p *parser) parseType() string {
+	start := p.tok.pos
+	p.parseConditionalType()
+	return p.text(start, p.lastEnd())
+}
+
+func (p *parser) parseReturnType() string {
+	start := p.tok.pos
+	if p.at("asserts") && p.peek().kind == tokIdent && !p.peek().nl {
+		p.next()
+	}
+	p.parseConditionalType()
+	if p.at("is") && !p.tok.nl {
+		p.next()
+		p.parseConditionalType()
+	}
+	return p.text(start, p.lastEnd())
+}
+
+func (p *parser) parseConditionalType() {
+	p.parseUnionType()
+	if p.at("extends") && !p.tok.nl {
+		p.next()
+		p.parseUnionType()
+		p.expect("?")
+		p.parseConditionalType()
+		p.expect(":")
+		p.parseConditionalType()
+	}
+}
+
+func (p *parser) parseUnionType() {
+	if p.at("|") || p.at("&") {
+		p.next()
+	}
+	p.parseTypeOperand()
+	for p.at("|") || p.at("&") {
+		p.next()
+		p.parseTypeOperand()
+	}
+}
+
+func (p *parser) parseTypeOperand() {
+	if (p.at("keyof") || p.at("unique") || p.at("readonly") || p.at("infer")) && p.peek().kind != tokPunct {
+		p.next()
+		p.parseTypeOperand()
+		return
+	}
+	p.parsePrimaryType()
+	for p.at("[") && !p.tok.nl {
+		p.next()
+		if !p.at("]") {
+			p.parseType()
+		}
+		p.expect("]")
+	}
+}
+
+func (p *parser) parsePrimaryType() {
+	switch {
+	case p.at("("):
+		// Tipo función "(a: T) => R" o tipo entre paréntesis
+		if p.try(func() {
+			p.parseParams()
+			p.expect("=>")
+		}) {
+			p.parseReturnType()
+			return
+		}
+		p.next()
+		p.parseType()
+		p.expect(")")
+	case p.at("<"):
+		p.parseTypeParams()
+		p.parseParams()
+		p.expect("=>")
+		p.parseReturnType()
+	case p.at("new") || (p.at("abstract") && p.peek().val == "new"):
+		p.eat("abstract")
+		p.next()
+		if p.at("<") {
+			p.parseTypeParams()
+		}
+		p.parseParams()
+		p.expect("=>")
+		p.parseReturnType()
+	case p.at("{"):
+		p.parseTypeMembers()
+	case p.at("["):
+		p.next()
+		for !p.at("]") {
+			p.eat("...")
+			if p.tok.kind == tokIdent && (p.peek().val == ":" || p.peek().val == "?") {
+				p.next()
+				p.eat("?")
+				p.expect(":")
+			}
+			p.parseType()
+			p.eat("?")
+			if !p.eat(",") {
+				break
+			}
+		}
+		p.expect("]")
+	case p.at("typeof"):
+		p.next()
+		if p.at("import") {
+			p.next()
+			p.skipBalanced("(", ")")
+		} else {
+			p.expectIdent()
+		}
+		for p.eat(".") {
+			p.parsePropertyName()
+		}
+		if p.at("<") && !p.tok.nl {
+			p.parseTypeArgs()
+		}
+	case p.at("import"):
+		p.next()
+		p.skipBalanced("(", ")")
+		for p.eat(".") {
+			p.expectIdent()
+		}
+		if p.at("<") {
+			p.parseTypeArgs()
+		}
+	case p.at("-"):
+		p.next()
+		if p.tok.kind != tokNumber {
+			p.failHere("se esperaba un número")
+		}
+		p.next()
+	case p.tok.kind == tokString || p.tok.kind == tokNumber || p.tok.kind == tokTemplate:
+		p.next()
+	case p.tok.kind == tokIdent:
+		p.next()
+		for p.at(".") {
+			p.next()
+			p.expectIdent()
+		}
+		if p.at("<") && !p.tok.nl {
+			p.parseTypeArgs()
+		}
+	default:
+		p.failHere("se esperaba un tipo")
+	}
+}
+
+// Miembros de un objeto tipo o una interfaz: { a: string; b?(): void }
+func (p *parser) parseTypeMembers() []*typeMember {
+	var members []*typeMember
+	p.expect("{")
+	for !p.at("}") {
+		if p.tok.kind == tokEOF {
+			p.failHere("se esperaba '}'")
+		}
+		start := p.tok.pos
+		member := &typeMember{}
+
+		if (p.at("readonly") || p.at("get") || p.at("set")) && p.peek().kind != tokPunct {
+			p.next()
+		}
+		if p.at("+") || p.at("-") {
+			p.next()
+			p.eat("readonly")
+		}
+
+		switch {
+		case p.at("[") || p.at("(") || p.at("<") || p.at("new"):
+			// Firmas de índice, de llamada o de construcción y tipos mapeados
+			if p.at("[") {
+				p.skipBalanced("[", "]")
+			} else {
+				p.eat("new")
+				fn := &function{}
+				p.parseSignature(fn)
+			}
+		default:
+			member.name = p.parsePropertyName()
+		}
+
+		if p.at("+") || p.at("-") {
+			p.next()
+		}
+		if p.eat("?") {
+			member.optional = true
+		}
+		if p.at("(") || p.at("<") {
+			// Método: nombre(params): Tipo
+			sigStart := p.tok.pos
+			fn := &function{}
+			p.parseSignature(fn)
+			member.typ = p.text(sigStart, p.lastEnd())
+			if fn.returnType != "" {
+				params := p.text(sigStart, p.lastEnd()-len(fn.returnType))
+				params = strings.TrimSuffix(strings.TrimSpace(params), ":")
+				member.typ = strings.TrimSpace(params) + " => " + fn.returnType
+			}
+		} else if p.eat(":") {
+			member.typ = p.parseType()
+		}
+
+		member.span = span{start, p.lastEnd()}
+		if member.name != "" {
+			members = append(members, member)
+		}
+		if !p.eat(";") && !p.eat(",") && !p.at("}") && !p.tok.nl {
+			p.failHere("se esperaba ';'")
+		}
+	}
+	p.expect("}")
+	return members
+}
+
+// Analizar un tipo objeto escrito como texto: "{ a: string; b?: number }"
+func parseTypeLiteral(src string) (members []*typeMember, err error) {
+	p := &parser{src: src, limit: len(src)}
+	defer p.recover(&err)
+
+	p.tok = p.scan(0, true)
+	return p.parseTypeMembers(), nil
+}
+
+// Parámetros de tipo: <T, K extends keyof T = keyof T>
+func (p *parser) parseTypeParams() string {
+	start := p.tok.pos
+	p.expect("<")
+	for !p.at(">") {
+		p.eat("const")
+		p.eat("in")
+		p.eat("out")
+		p.expectIdent()
+		if p.at("extends") {
+			p.next()
+			p.parseType()
+		}
+		if p.eat("=") {
+			p.parseType()
+		}
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect(">")
+	return p.text(start, p.lastEnd())
+}
+
+func (p *parser) parseTypeArgs() string {
+	start := p.tok.pos
+	p.expect("<")
+	for !p.at(">") {
+		p.parseType()
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect(">")
+	return p.text(start, p.lastEnd())
+}
+
+// Saltar tokens hasta cerrar el delimitador abierto en la posición actual
+func (p *parser) skipBalanced(open, close string) {
+	start := p.tok.pos
+	p.expect(open)
+	depth := 1
+	for depth > 0 {
+		if p.tok.kind == tokEOF {
+			p.fail(start, "no se pudo emparejar %q", open)
+		}
+		if p.tok.kind == tokPunct {
+			switch p.tok.val {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+		}
+		p.next()
+	}
+}
+
+func closerOf(open string) string {
+	switch open {
+	case "(":
+		return ")"
+	case "[":
+		return "]"
+	}
+	return "}"
+}
+
+// ---- Expresiones ----
+
+func (p *parser) parseExpression() node {
+	start := p.tok.pos
+	expr := p.parseAssign()
+	if !p.at(",") {
+		return expr
+	}
+	seq := &sequenceExpr{exprs: []node{expr}}
+	for p.eat(",") {
+		seq.exprs = append(seq.exprs, p.parseAssign())
+	}
+	seq.span = span{start, p.lastEnd()}
+	return seq
+}
+
+// Expresión en la cabecera de un for, donde "in" no es un operador binario
+func (p *parser) parseExpressionNoIn() node {
+	start := p.tok.pos
+	expr := p.parseBinary(0, true)
+	if p.eat("=") {
+		value := p.parseAssign()
+		return &assignExpr{span{start, p.lastEnd()}, "=", expr, value}
+	}
+	return expr
+}
+
+var assignOps = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true,
+	"**=": true, "<<=": true, ">>=": true, ">>>=": true, "&=": true, "|=": true,
+	"^=": true, "&&=": true, "||=": true, "??=": true,
+}
+
+func (p *parser) parseAssign() node {
+	start := p.tok.pos
+
+	if fn := p.tryArrow(); fn != nil {
+		return fn
+	}
+	if p.at("yield") {
+		p.next()
+		p.eat("*")
+		var arg node
+		if !p.at(")") && !p.at("]") && !p.at("}") && !p.at(",") && !p.at(";") && !p.tok.nl {
+			arg = p.parseAssign()
+		}
+		return &unaryExpr{span{start, p.lastEnd()}, "yield", arg}
+	}
+
+	left := p.parseConditional()
+	p.combineGreater()
+	if p.tok.kind == tokPunct && assignOps[p.tok.val] {
+		op := p.tok.val
+		p.next()
+		value := p.parseAssign()
+		return &assignExpr{span{start, p.lastEnd()}, op, left, value}
+	}
+	return left
+}
+
+// Reconstruir operadores que empiezan por '>' (>=, >>, >>>, >>=, >>>=)
+func (p *parser) combineGreater() {
+	if p.tok.kind != tokPunct || p.tok.val != ">" {
+		return
+	}
+	for _, op := range []string{">>>=", ">>>", ">>=", ">>", ">="} {
+		if strings.HasPrefix(p.src[p.tok.pos:p.limit], op) {
+			p.tok.val = op
+			p.tok.end = p.tok.pos + len(op)
+			return
+		}
+	}
+}
+
+// Intentar analizar una arrow function; devuelve nil si no lo es
+func (p *parser) tryArrow() *function {
+	start := p.tok.pos
+	fn := &function{arrow: true}
+
+	isCandidate := p.at("(") || p.at("<") ||
+		(p.tok.kind == tokIdent && (p.peek().val == "=>" || p.at("async")))
+	if !isCandidate {
+		return nil
+	}
+
+	ok := p.try(func() {
+		if p.at("async") {
+			next := p.peek()
+			if !next.nl && (next.val == "(" || next.val == "<" || (next.kind == tokIdent && next.val != "=>")) {
+				fn.async = true
+				p.next()
+			}
+		}
+		if p.tok.kind == tokIdent {
+			paramStart := p.tok.pos
+			name := p.expectIdent()
+			id := &ident{span{paramStart, p.lastEnd()}, name}
+			fn.params = []*param{{span: id.span, target: id}}
+		} else {
+			p.parseSignature(fn)
+		}
+		if !p.at("=>") || p.tok.nl {
+			p.failHere("se esperaba '=>'")
+		}
+		p.next()
+	})
+	if !ok {
+		return nil
+	}
+
+	if p.at("{") {
+		fn.body = p.parseBlock()
+	} else {
+		fn.expr = p.parseAssign()
+	}
+	fn.span = span{start, p.lastEnd()}
+	return fn
+}
+
+func (p *parser) parseConditional() node {
+	start := p.tok.pos
+	test := p.parseBinary(0, false)
+	if !p.at("?") {
+		return test
+	}
+	p.next()
+	cons := p.parseAssign()
+	p.expect(":")
+	alt := p.parseAssign()
+	return &conditionalExpr{span{start, p.lastEnd()}, test, cons, alt}
+}
+
+var binaryPrecedence = map[string]int{
+	"??": 1,
+	"||": 2, "&&": 3, "|": 4, "^": 5, "&": 6,
+	"==": 7, "!=": 7, "===": 7, "!==": 7,
+	"<": 8, ">": 8, "<=": 8, ">=": 8, "instanceof": 8, "in": 8, "as": 8, "satisfies": 8,
+	"<<": 9, ">>": 9, ">>>": 9,
+	"+": 10, "-": 10,
+	"*": 11, "/": 11, "%": 11,
+	"**": 12,
+}
+
+func (p *parser) parseBinary(minPrec int, noIn bool) node {
+	start := p.tok.pos
+	left := p.parseUnary()
+	for {
+		p.combineGreater()
+		op := p.tok.val
+		if p.tok.kind != tokPunct && !(p.tok.kind == tokIdent && (op == "instanceof" || op == "in" || op == "as" || op == "satisfies")) {
+			return left
+		}
+		prec, ok := binaryPrecedence[op]
+		if !ok || prec <= minPrec || (noIn && op == "in") {
+			return left
+		}
+		if (op == "as" || op == "satisfies") && p.tok.nl {
+			return left
+		}
+		p.next()
+
+		if op == "as" || op == "satisfies" {
+			typ := "const"
+			if !p.eat("const") {
+				typ = p.parseType()
+			}
+			left = &asExpr{span{start, p.lastEnd()}, left, op, typ}
+			continue
+		}
+
+		nextMin := prec
+		if op == "**" {
+			nextMin = prec - 1
+		}
+		right := p.parseBinary(nextMin, noIn)
+		left = &binaryExpr{span{start, p.lastEnd()}, op, left, right}
+	}
+}
+
+func (p *parser) parseUnary() node {
+	start := p.tok.pos
+	if p.tok.kind == tokPunct {
+		switch p.tok.val {
+		case "!", "~", "+", "-":
+			op := p.tok.val
+			p.next()
+			arg := p.parseUnary()
+			return &unaryExpr{span{start, p.lastEnd()}, op, arg}
+		case "++", "--":
+			op := p.tok.val
+			p.next()
+			arg := p.parseUnary()
+			return &updateExpr{span{start, p.lastEnd()}, op, true, arg}
+		}
+	}
+	if p.tok.kind == tokIdent {
+		switch p.tok.val {
+		case "typeof", "void", "delete", "await":
+			next := p.peek()
+			if next.kind != tokPunct || strings.Contains("([{!~+-`/<", next.val) || next.val == "++" || next.val == "--" {
+				op := p.tok.val
+				p.next()
+				arg := p.parseUnary()
+				return &unaryExpr{span{start, p.lastEnd()}, op, arg}
+			}
+		}
+	}
+
+	expr := p.parseLeftHandSide()
+	if (p.at("++") || p.at("--")) && !p.tok.nl {
+		op := p.tok.val
+		p.next()
+		return &updateExpr{span{start, p.lastEnd()}, op, false, expr}
+	}
+	return expr
+}
+
+// Expresión primaria seguida de accesos a miembros, llamadas y demás sufijos
+func (p *parser) parseLeftHandSide() node {
+	start := p.tok.pos
+	expr := p.parsePrimary()
+
+	for {
+		switch {
+		case p.at("."):
+			p.next()
+			name := p.parsePropertyName()
+			expr = &memberExpr{span{start, p.lastEnd()}, expr, name, false}
+		case p.at("?."):
+			p.next()
+			switch {
+			case p.at("("):
+				expr = p.finishCall(start, expr, "", true)
+			case p.at("["):
+				p.next()
+				index := p.parseExpression()
+				p.expect("]")
+				expr = &indexExpr{span{start, p.lastEnd()}, expr, index, true}
+			default:
+				name := p.parsePropertyName()
+				expr = &memberExpr{span{start, p.lastEnd()}, expr, name, true}
+			}
+		case p.at("["):
+			p.next()
+			index := p.parseExpression()
+			p.expect("]")
+			expr = &indexExpr{span{start, p.lastEnd()}, expr, index, false}
+		case p.at("("):
+			expr = p.finishCall(start, expr, "", false)
+		case p.at("<") && !p.tok.nl:
+			// Argumentos de tipo en una llamada: fn<T>(x)
+			typeArgs := ""
+			if !p.try(func() {
+				typeArgs = p.parseTypeArgs()
+				if !p.at("(") {
+					p.failHere("se esperaba '('")
+				}
+			}) {
+				return expr
+			}
+			expr = p.finishCall(start, expr, typeArgs, false)
+		case p.tok.kind == tokTemplate:
+			quasi := p.parseTemplate()
+			expr = &taggedTemplate{span{start, p.lastEnd()}, expr, quasi}
+		case p.at("!") && !p.tok.nl:
+			p.next()
+			expr = &nonNullExpr{span{start, p.lastEnd()}, expr}
+		default:
+			return expr
+		}
+	}
+}
+
+func (p *parser) finishCall(start int, callee node, typeArgs string, optional bool) node {
+	args := p.parseArguments()
+	call := callExpr{span{start, p.lastEnd()}, callee, typeArgs, args, optional}
+	if name := hookName(callee); name != "" {
+		return &hookCall{call, name}
+	}
+	return &call
+}
+
+// Nombre del hook si el callee es useX o React.useX
+func hookName(callee node) string {
+	switch c := callee.(type) {
+	case *ident:
+		if hookNameRegex.MatchString(c.name) {
+			return c.name
+		}
+	case *memberExpr:
+		if obj, ok := c.object.(*ident); ok && obj.name == "React" && hookNameRegex.MatchString(c.property) {
+			return c.property
+		}
+	}
+	return ""
+}
+
+func (p *parser) parseArguments() []node {
+	var args []node
+	p.expect("(")
+	for !p.at(")") {
+		args = append(args, p.parseSpreadOrAssign())
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect(")")
+	return args
+}
+
+func (p *parser) parseSpreadOrAssign() node {
+	start := p.tok.pos
+	if p.eat("...") {
+		arg := p.parseAssign()
+		return &spreadElem{span{start, p.lastEnd()}, arg}
+	}
+	return p.parseAssign()
+}
+
+func (p *parser) parsePrimary() node {
+	start := p.tok.pos
+	// En posición de operando una '/' solo puede iniciar una expresión
+	// regular, aunque el token anterior la hiciera parecer una división:
+	// if (x) /re/.test(y)
+	if p.tok.kind == tokPunct && (p.tok.val == "/" || p.tok.val == "/=") {
+		nl := p.tok.nl
+		p.tok = p.scan(start, true)
+		p.tok.nl = nl
+	}
+	tok := p.tok
+
+	switch tok.kind {
+	case tokNumber:
+		p.next()
+		return &literal{span{start, tok.end}, litNumber, tok.val}
+	case tokString:
+		p.next()
+		return &literal{span{start, tok.end}, litString, tok.val}
+	case tokRegex:
+		p.next()
+		return &literal{span{start, tok.end}, litRegex, tok.val}
+	case tokTemplate:
+		return p.parseTemplate()
+	case tokIdent:
+		switch tok.val {
+		case "true", "false":
+			p.next()
+			return &literal{span{start, tok.end}, litBool, tok.val}
+		case "null":
+			p.next()
+			return &literal{span{start, tok.end}, litNull, tok.val}
+		case "function":
+			return p.parseFunction(start, false)
+		case "async":
+			if next := p.peek(); next.val == "function" && !next.nl {
+				p.next()
+				return p.parseFunction(start, true)
+			}
+		case "class":
+			p.parseClass(start)
+			return &classExpr{span{start, p.lastEnd()}, ""}
+		case "new":
+			return p.parseNew()
+		}
+		p.next()
+		return &ident{span{start, tok.end}, tok.val}
+	case tokPunct:
+		switch tok.val {
+		case "(":
+			p.next()
+			expr := p.parseExpression()
+			p.expect(")")
+			return &parenExpr{span{start, p.lastEnd()}, expr}
+		case "[":
+			return p.parseArray()
+		case "{":
+			return p.parseObject()
+		case "<":
+			return p.parseJSX(false)
+		}
+	}
+
+	p.failHere("expresión inesperada")
+	return nil
+}
+
+func (p *parser) parseNew() node {
+	start := p.tok.pos
+	p.expect("new")
+	if p.at(".") {
+		p.next()
+		name := p.expectIdent()
+		return &memberExpr{span{start, p.lastEnd()}, &ident{span{start, start + 3}, "new"}, name, false}
+	}
+
+	calleeStart := p.tok.pos
+	var callee node
+	if p.at("new") {
+		callee = p.parseNew()
+	} else {
+		callee = p.parsePrimary()
+	}
+	for {
+		if p.at(".") {
+			p.next()
+			name := p.parsePropertyName()
+			callee = &memberExpr{span{calleeStart, p.lastEnd()}, callee, name, false}
+		} else if p.at("[") {
+			p.next()
+			index := p.parseExpression()
+			p.expect("]")
+			callee = &indexExpr{span{calleeStart, p.lastEnd()}, callee, index, false}
+		} else {
+			break
+		}
+	}
+	if p.at("<") {
+		p.try(func() {
+			p.parseTypeArgs()
+			if !p.at("(") {
+				p.failHere("se esperaba '('")
+			}
+		})
+	}
+	var args []node
+	if p.at("(") {
+		args = p.parseArguments()
+	}
+	return &newExpr{span{start, p.lastEnd()}, callee, args}
+}
+
+func (p *parser) parseTemplate() *templateLit {
+	tok := p.tok
+	tmpl := &templateLit{span: span{tok.pos, tok.end}}
+	for _, sub := range tok.subs {
+		tmpl.exprs = append(tmpl.exprs, p.parseSubExpression(sub))
+	}
+	p.next()
+	return tmpl
+}
+
+// Analizar una expresión incrustada (por ejemplo ${...} en un template)
+func (p *parser) parseSubExpression(s span) node {
+	sub := &parser{src: p.src, limit: s.end}
+	sub.tok = sub.scan(s.start, true)
+	expr := sub.parseExpression()
+	if sub.tok.kind != tokEOF {
+		sub.failHere("se esperaba '}'")
+	}
+	return expr
+}
+
+func (p *parser) parseArray() node {
+	start := p.tok.pos
+	p.expect("[")
+	arr := &arrayLit{}
+	for !p.at("]") {
+		if p.at(",") {
+			p.next()
+			arr.elems = append(arr.elems, nil)
+			continue
+		}
+		arr.elems = append(arr.elems, p.parseSpreadOrAssign())
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect("]")
+	arr.span = span{start, p.lastEnd()}
+	return arr
+}
+
+func (p *parser) parseObject() node {
+	start := p.tok.pos
+	p.expect("{")
+	obj := &objectLit{}
+	for !p.at("}") {
+		obj.props = append(obj.props, p.parseProperty())
+		if !p.eat(",") {
+			break
+		}
+	}
+	p.expect("}")
+	obj.span = span{start, p.lastEnd()}
+	return obj
+}
+
+func (p *parser) parseProperty() *property {
+	start := p.tok.pos
+	prop := &property{}
+
+	if p.eat("...") {
+		prop.spread = true
+		prop.value = p.parseAssign()
+		prop.span = span{start, p.lastEnd()}
+		return prop
+	}
+
+	// Modificadores de métodos: get, set, async, *
+	async, generator := false, false
+	for {
+		next := p.peek()
+		isModifier := (p.at("get") || p.at("set") || p.at("async")) &&
+			next.val != ":" && next.val != "(" && next.val != "," && next.val != "}" && next.val != "=" && next.val != "<"
+		if isModifier {
+			async = async || p.at("async")
+			p.next()
+			continue
+		}
+		if p.at("*") {
+			generator = true
+			p.next()
+			continue
+		}
+		break
+	}
+
+	keyStart := p.tok.pos
+	if p.at("[") {
+		p.next()
+		prop.computed = true
+		prop.key = p.parseAssign()
+		p.expect("]")
+	} else if p.tok.kind == tokString || p.tok.kind == tokNumber {
+		tok := p.tok
+		p.next()
+		kind := litString
+		if tok.kind == tokNumber {
+			kind = litNumber
+		}
+		prop.key = &literal{span{keyStart, tok.end}, kind, tok.val}
+	} else {
+		name := p.expectIdent()
+		prop.key = &ident{span{keyStart, p.lastEnd()}, name}
+	}
+
+	switch {
+	case p.at("(") || p.at("<"):
+		sigStart := p.tok.pos
+		fn := &function{async: async, generator: generator}
+		p.parseSignature(fn)
+		fn.body = p.parseBlock()
+		fn.span = span{sigStart, p.lastEnd()}
+		prop.value = fn
+	case p.eat(":"):
+		prop.value = p.parseAssign()
+	default:
+		prop.shorthand = true
+		prop.value = prop.key
+		if p.eat("=") {
+			prop.def = p.parseAssign()
+		}
+	}
+	prop.span = span{start, p.lastEnd()}
+	return prop
+}
+
+// ---- JSX ----
+
+// Analizar un elemento o fragmento JSX. inChildren indica si el elemento es
+// hijo de otro elemento, lo que determina cómo se escanea el token siguiente.
+func (p *parser) parseJSX(inChildren bool) node {
+	start := p.tok.pos
+	p.nextJSXTag()
+
+	if p.at(">") {
+		p.nextJSXChild()
+		children := p.parseJSXChildren(start)
+		p.parseJSXClosing(start, "", inChildren)
+		return &jsxFragment{span{start, p.lastEnd()}, children}
+	}
+
+	el := &jsxElement{name: p.parseJSXName()}
+	for {
+		switch {
+		case p.at("/"):
+			p.nextJSXTag()
+			if !p.at(">") {
+				p.failHere("se esperaba '>'")
+			}
+			el.selfClosing = true
+			el.openEnd = p.tok.end
+			p.finishJSX(inChildren)
+			el.span = span{start, p.lastEnd()}
+			return el
+		case p.at(">"):
+			el.openEnd = p.tok.end
+			p.nextJSXChild()
+			el.children = p.parseJSXChildren(start)
+			p.parseJSXClosing(start, el.name, inChildren)
+			el.span = span{start, p.lastEnd()}
+			return el
+		case p.at("{"):
+			attrStart := p.tok.pos
+			p.next()
+			p.expect("...")
+			arg := p.parseAssign()
+			p.expectJSXClose()
+			el.attrs = append(el.attrs, &jsxSpreadAttr{span{attrStart, p.lastEnd()}, arg})
+		case p.tok.kind == tokIdent:
+			el.attrs = append(el.attrs, p.parseJSXAttr())
+		default:
+			if p.tok.kind == tokEOF {
+				p.fail(start, "etiqueta JSX <%s> sin cerrar", el.name)
+			}
+			p.failHere("atributo JSX inesperado")
+		}
+	}
+}
+
+func (p *parser) parseJSXName() string {
+	if p.tok.kind != tokIdent {
+		p.failHere("se esperaba el nombre de la etiqueta JSX")
+	}
+	start := p.tok.pos
+	p.nextJSXTag()
+	for (p.at(".") || p.at(":")) && p.prev.end == p.tok.pos {
+		p.nextJSXTag()
+		if p.tok.kind != tokIdent {
+			p.failHere("se esperaba un identificador")
+		}
+		p.nextJSXTag()
+	}
+	return p.text(start, p.lastEnd())
+}
+
+func (p *parser) parseJSXAttr() node {
+	start := p.tok.pos
+	attr := &jsxAttr{name: p.tok.val}
+	p.nextJSXTag()
+	if p.at(":") && p.prev.end == p.tok.pos {
+		p.nextJSXTag()
+		attr.name += ":" + p.tok.val
+		p.nextJSXTag()
+	}
+	if p.at("=") {
+		p.nextJSXTag()
+		switch {
+		case p.tok.kind == tokString:
+			attr.value = &literal{span{p.tok.pos, p.tok.end}, litString, p.tok.val}
+			p.nextJSXTag()
+		case p.at("{"):
+			containerStart := p.tok.pos
+			p.next()
+			var expr node
+			if !p.at("}") {
+				expr = p.parseAssign()
+			}
+			p.expectJSXClose()
+			attr.value = &jsxExprContainer{span{containerStart, p.lastEnd()}, expr}
+		case p.at("<"):
+			attr.value = p.parseJSX(false)
+			// parseJSX escanea en modo JavaScript; volver al modo de etiqueta
+			p.tok = p.scanJSXTag(p.prev.end)
+		default:
+			p.failHere("se esperaba el valor del atributo %s", attr.name)
+		}
+	}
+	attr.span = span{start, p.lastEnd()}
+	return attr
+}
+
+// Consumir la '}' que cierra una expresión dentro de una etiqueta
+func (p *parser) expectJSXClose() {
+	if !p.at("}") {
+		p.failHere("se esperaba '}'")
+	}
+	p.nextJSXTag()
+}
+
+func (p *parser) parseJSXChildren(start int) []node {
+	var children []node
+	for {
+		switch {
+		case p.tok.kind == tokEOF:
+			p.fail(start, "elemento JSX sin cerrar")
+		case p.tok.kind == tokJSXText:
+			children = append(children, &jsxText{span{p.tok.pos, p.tok.end}, p.tok.val})
+			p.nextJSXChild()
+		case p.at("{"):
+			containerStart := p.tok.pos
+			p.next()
+			var expr node
+			if p.at("...") {
+				expr = p.parseSpreadOrAssign()
+			} else if !p.at("}") {
+				expr = p.parseExpression()
+			}
+			if !p.at("}") {
+				p.failHere("se esperaba '}'")
+			}
+			children = append(children, &jsxExprContainer{span{containerStart, p.tok.end}, expr})
+			p.nextJSXChild()
+		case p.at("<"):
+			if next := p.scanJSXTag(p.tok.end); next.val == "/" {
+				return children
+			}
+			children = append(children, p.parseJSX(true))
+		default:
+			p.failHere("contenido JSX inesperado")
+		}
+	}
+}
+
+func (p *parser) parseJSXClosing(start int, name string, inChildren bool) {
+	p.nextJSXTag() // '/'
+	p.nextJSXTag()
+	closing := ""
+	if !p.at(">") {
+		closing = p.parseJSXName()
+	}
+	if closing != name {
+		p.fail(start, "la etiqueta <%s> se cierra con </%s>", name, closing)
+	}
+	if !p.at(">") {
+		p.failHere("se esperaba '>'")
+	}
+	p.finishJSX(inChildren)
+}
+
+// Avanzar tras el '>' final de un elemento según el contexto
+func (p *parser) finishJSX(inChildren bool) {
+	if inChildren {
+		p.nextJSXChild()
+	} else {
+		p.prev = p.tok
+		p.tok = p.scan(p.tok.end, false)
+	}
+}
+
+// Quitar las comillas de un literal de cadena
+func unquote(s string) string {
+	if len(s) >= 2 {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
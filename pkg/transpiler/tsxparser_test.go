@@ -0,0 +1,130 @@
+package transpiler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Forma del árbol sintáctico: (tipo hijos...)
+func shape(n node) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", n), "*transpiler.")
+	kids := children(n)
+	if len(kids) == 0 {
+		return name
+	}
+	parts := []string{name}
+	for _, kid := range kids {
+		parts = append(parts, shape(kid))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestParseExpressions(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		// Expresiones regulares y divisiones
+		{"división", "a / b / c", "(exprStmt (binaryExpr (binaryExpr ident ident) ident))"},
+		{"regex tras if", "if (x) /re/.test(y)", "(ifStmt ident (exprStmt (callExpr (memberExpr literal) ident)))"},
+		{"regex en arrow", "const r = () => /a/", "(varDecl (declarator ident (function literal)))"},
+		{"división tras a++", "a++ / 2", "(exprStmt (binaryExpr (updateExpr ident) literal))"},
+
+		// Template literals
+		{"template anidado", "`a${b}c${`d${e}`}`", "(exprStmt (templateLit ident (templateLit ident)))"},
+		{"tagged template", "css`color: ${c}`", "(exprStmt (taggedTemplate ident (templateLit ident)))"},
+
+		// Texto JSX y genéricos
+		{"texto JSX con >", "<p>a > b {c}</p>", "(exprStmt (jsxElement jsxText (jsxExprContainer ident)))"},
+		{"comparaciones", "a < b && c > d", "(exprStmt (binaryExpr (binaryExpr ident ident) (binaryExpr ident ident)))"},
+		{"llamada genérica", "f<number>(x)", "(exprStmt (callExpr ident ident))"},
+		{"hook genérico", "useState<string[]>([])", "(exprStmt (hookCall ident arrayLit))"},
+		{"JSX dentro de un genérico", "const el = useMemo<JSX.Element>(() => <b />, [])", "(varDecl (declarator ident (hookCall ident (function jsxElement) arrayLit)))"},
+
+		// Arrows genéricas
+		{"arrow <T,>", "const f = <T,>(x: T) => x;", "(varDecl (declarator ident (function (param ident) ident)))"},
+		{"arrow <T extends>", "const g = <T extends object>(x: T): T => x;", "(varDecl (declarator ident (function (param ident) ident)))"},
+		{"arrow async <T,>", "const h = async <T,>(x: T) => x;", "(varDecl (declarator ident (function (param ident) ident)))"},
+	}
+	for _, tt := range tests {
+		prog, err := parseFile(tt.src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := shape(prog.body[0]); got != tt.want {
+			t.Errorf("%s: %s\n se esperaba %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		src string
+		pos int
+		msg string
+	}{
+		{"const = 1", 6, "se esperaba un identificador"},
+		{"foo(", 4, "fin de archivo inesperado"},
+		{"let {a,} = ;", 11, "expresión inesperada"},
+		{"'abc", 0, "cadena sin cerrar"},
+		{"x = `a${b", 4, "template literal sin cerrar"},
+		{"const x = <div>", 10, "elemento JSX sin cerrar"},
+		{"const y = <div></span>", 10, "se cierra con </span>"},
+		{"a ? b", 5, "se esperaba \":\""},
+	}
+	for _, tt := range tests {
+		_, err := parseFile(tt.src)
+		se, ok := err.(*syntaxError)
+		if !ok {
+			t.Errorf("%q: se esperaba un error de sintaxis, se obtuvo %v", tt.src, err)
+			continue
+		}
+		if se.pos != tt.pos || !strings.Contains(se.msg, tt.msg) {
+			t.Errorf("%q: %d %q, se esperaba %d %q", tt.src, se.pos, se.msg, tt.pos, tt.msg)
+		}
+	}
+}
+
+// Declaraciones de TypeScript que no se convierten pero se deben analizar
+func TestParseTypeScriptDeclarations(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string // tipo de la primera sentencia
+	}{
+		{"class Old extends React.Component<{ a: number }, {}> { render() { return <div />; } }", "*transpiler.classDecl"},
+		{"class A<T> extends B<T> implements C<{ x: T }> {}", "*transpiler.classDecl"},
+		{"declare global { interface Window { x: number } }", "*transpiler.namespaceDecl"},
+		{"declare module 'x' { export const y: number; }", "*transpiler.namespaceDecl"},
+		{"declare module 'y';", "*transpiler.namespaceDecl"},
+		{"declare const VERSION: string;", "*transpiler.emptyStmt"},
+		{"namespace NS.Inner { export const a = { b: 1 }; }", "*transpiler.namespaceDecl"},
+		{"module Legacy { const a = 1; }", "*transpiler.namespaceDecl"},
+		{"using res = open();", "*transpiler.varDecl"},
+		{"await using res = open();", "*transpiler.varDecl"},
+		{"module.exports = 1;", "*transpiler.exprStmt"},
+		{"await using;", "*transpiler.exprStmt"},
+		{"using instanceof Foo;", "*transpiler.exprStmt"},
+	}
+	for _, tt := range tests {
+		prog, err := parseFile(tt.src + "\nconst after = 1;")
+		if err != nil {
+			t.Errorf("%q: %v", tt.src, err)
+			continue
+		}
+		if len(prog.body) != 2 {
+			t.Errorf("%q: %d sentencias, se esperaban 2", tt.src, len(prog.body))
+			continue
+		}
+		if got := fmt.Sprintf("%T", prog.body[0]); got != tt.want {
+			t.Errorf("%q: %s, se esperaba %s", tt.src, got, tt.want)
+		}
+	}
+
+	prog, _ := parseFile("await using res = open();")
+	if vd, ok := prog.body[0].(*varDecl); !ok || vd.kind != "await using" || vd.start != 0 {
+		t.Errorf("await using: se obtuvo %#v", prog.body[0])
+	}
+}
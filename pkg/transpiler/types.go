@@ -2,13 +2,37 @@ package transpiler
 
 // Estructuras para representar el componente React
 type ReactComponent struct {
-	Name       string
-	Props      []PropDefinition
-	States     []StateDefinition
-	Effects    []EffectDefinition
-	Functions  []FunctionDefinition
+	Name         string
+	Props        []PropDefinition
+	PropsExtends string // atributos de svelte/elements que heredan las props
+	Script
 	JSXContent string
 	Imports    []string
+	Todos      []TodoDefinition
+
+	Contexts      []ContextDefinition
+	Hooks         []HookDefinition // hooks use* declarados en el archivo
+	Types         []string         // declaraciones de tipos del módulo que no son las props
+	Constants     []string         // constantes del módulo, que se conservan tal cual
+	SvelteImports []string         // funciones de svelte que usa el código convertido
+
+	ModuleFunctions []FunctionDefinition // funciones auxiliares del módulo
+	Module          bool                 // el código del módulo va en <script module>
+}
+
+// Declaraciones del script de un componente o del cuerpo de un hook. Se
+// emiten según su Order, la posición de la declaración en el código original,
+// para que cada una aparezca después de las que lee
+type Script struct {
+	Variables     []VariableDefinition
+	States        []StateDefinition
+	Reducers      []ReducerDefinition
+	Refs          []RefDefinition
+	HookUsages    []HookUsage
+	Derived       []DerivedDefinition
+	ContextUsages []ContextUsage
+	Functions     []FunctionDefinition
+	Effects       []EffectDefinition
 }
 
 type PropDefinition struct {
@@ -16,22 +40,136 @@ type PropDefinition struct {
 	Type         string
 	DefaultValue string
 	Optional     bool
+	Rest         bool // ...rest: el resto de props
+	InRest       bool // solo declarada en el tipo; llega dentro de ...rest
+}
+
+// Variable del componente o de un hook que no depende de valores reactivos y
+// se conserva tal cual
+type VariableDefinition struct {
+	Code  string
+	Order int
 }
 
 type StateDefinition struct {
 	Name         string
+	Setter       string
 	Type         string
 	InitialValue string
+	Order        int
 }
 
-type EffectDefinition struct {
+// Estado de useReducer: se emite como $state junto a una función dispatch que
+// aplica el reducer
+type ReducerDefinition struct {
+	Name         string // variable de estado
+	Dispatch     string // vacío si no se usa
+	Reducer      string // función reductora o expresión que la produce
+	Type         string
+	ActionType   string
+	InitialValue string
+	Order        int
+}
+
+// Ref creada con useRef. Las refs de elementos del DOM se enlazan con
+// bind:this; el resto son variables normales
+type RefDefinition struct {
+	Name         string
+	Type         string
+	InitialValue string
+	DOM          bool
+	Component    bool // se enlaza con bind:ref a un componente, que exige $state
+	Order        int
+}
+
+// Valor derivado de otros valores reactivos. Se emite como $derived(Expression)
+// o, si el cálculo necesita sentencias, como $derived.by(() => { Body })
+type DerivedDefinition struct {
+	Name         string // identificador o patrón de desestructuración
+	Type         string
+	Expression   string
+	Body         string
 	Dependencies []string
+	Order        int
+}
+
+// Tipo de efecto de Svelte
+type EffectKind int
+
+const (
+	EffectKindEffect EffectKind = iota // $effect (useEffect)
+	EffectKindPre                      // $effect.pre (useLayoutEffect, useInsertionEffect)
+)
+
+func (k EffectKind) String() string {
+	if k == EffectKindPre {
+		return "$effect.pre"
+	}
+	return "$effect"
+}
+
+type EffectDefinition struct {
+	Kind         EffectKind
+	Dependencies []string // nil si el hook no recibe lista de dependencias
 	Body         string
+	Untrack      bool // el cuerpo se ejecuta con untrack tras leer las dependencias
+	Cleanup      bool // el cuerpo devuelve una función de limpieza
+	Order        int
 }
 
 type FunctionDefinition struct {
-	Name   string
-	Body   string
-	Async  bool
-	Params string
+	Name       string
+	Body       string
+	Async      bool
+	Params     string
+	ReturnType string
+	Exported   bool
+	Order      int
+}
+
+// Contexto creado con createContext. Se exporta como una clave junto con las
+// funciones setName y getName
+type ContextDefinition struct {
+	Name          string
+	Type          string
+	DefaultValue  string // valor cuando ningún componente superior lo provee
+	ExportDefault bool
+}
+
+// Lectura (const theme = getThemeContext()) o escritura (setThemeContext(v))
+// de un contexto desde el componente
+type ContextUsage struct {
+	Kind       string // const o let; vacío en las escrituras
+	Target     string // variable o patrón que recibe el valor
+	Expression string
+	Order      int
+}
+
+// Hook personalizado (función use*) convertido en una función de un módulo
+// .svelte.ts. Return devuelve los valores reactivos mediante getters para que
+// quien llama al hook siga viendo sus cambios
+type HookDefinition struct {
+	Name          string
+	Params        string
+	ReturnType    string
+	Exported      bool
+	ExportDefault bool
+	Script
+	Return string
+}
+
+// Llamada a un hook personalizado. Las variables de una desestructuración se
+// sustituyen por accesos a Target (counter.count) para no perder reactividad
+type HookUsage struct {
+	Kind       string // const o let; vacío si el resultado no se usa
+	Target     string
+	Expression string
+	Order      int
+}
+
+// Construcción que no se pudo convertir y queda pendiente de revisión manual
+type TodoDefinition struct {
+	Line    int
+	Message string
+	Source  string // código original omitido, si lo hay
 }